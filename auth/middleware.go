@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey is the gin.Context key Middleware stores the matched
+// Principal under.
+const principalKey = "auth.principal"
+
+// Middleware authenticates requests with a bearer token or API key from
+// the Authorization header, or a principal already attached to the
+// context by MTLSPrincipalMiddleware. Unauthenticated
+// requests receive a 401 with a structured error body.
+func Middleware(store *Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := FromContext(c); ok {
+			// Already authenticated upstream, e.g. via mTLS.
+			c.Next()
+			return
+		}
+
+		token := bearerToken(c.GetHeader("Authorization"))
+		principal, ok := store.Authenticate(token)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    http.StatusUnauthorized,
+				"message": "missing or invalid credentials",
+			})
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// bearerToken extracts the token from a "Bearer <token>" header value.
+func bearerToken(header string) string {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// FromContext returns the Principal attached to c by Middleware or the
+// mTLS server, if any.
+func FromContext(c *gin.Context) (*Principal, bool) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	p, ok := v.(*Principal)
+	return p, ok
+}
+
+// SetPrincipal attaches p to c, for use by MTLSPrincipalMiddleware ahead
+// of Middleware in the handler chain.
+func SetPrincipal(c *gin.Context, p *Principal) {
+	c.Set(principalKey, p)
+}
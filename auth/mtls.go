@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MTLSConfig configures the parallel mTLS listener.
+type MTLSConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Addr    string `yaml:"addr" json:"addr"`
+	// CertFile/KeyFile are the server's own certificate and key.
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	// ClientCAFile is a PEM bundle of CAs trusted to sign client certs.
+	ClientCAFile string `yaml:"client_ca_file" json:"client_ca_file"`
+	// Principals maps a client certificate's CommonName to the
+	// platforms/topics it may push to, mirroring APIKey.Platforms/Topics.
+	// A CA-signed client cert only proves the client is *someone* this
+	// server trusts, not *which* tenant -- without an entry here, every
+	// holder of a CA-signed cert would get Principal.AllowsPlatform/
+	// AllowsTopic's "empty means unrestricted" default, i.e. push access
+	// to every platform and topic. If Principals is non-empty, a
+	// CommonName with no matching entry is treated as unauthenticated
+	// (see MTLSPrincipalMiddleware) rather than silently falling back to
+	// that unrestricted default.
+	Principals []MTLSPrincipal `yaml:"principals" json:"principals"`
+}
+
+// MTLSPrincipal scopes one client certificate CommonName to the
+// platforms/topics it may push to.
+type MTLSPrincipal struct {
+	CommonName string   `yaml:"common_name" json:"common_name"`
+	Platforms  []string `yaml:"platforms" json:"platforms"`
+	Topics     []string `yaml:"topics" json:"topics"`
+}
+
+// scopeFor looks up the MTLSPrincipal configured for cn, if any.
+func (cfg MTLSConfig) scopeFor(cn string) (MTLSPrincipal, bool) {
+	for _, p := range cfg.Principals {
+		if p.CommonName == cn {
+			return p, true
+		}
+	}
+	return MTLSPrincipal{}, false
+}
+
+// TLSConfig builds the *tls.Config an mTLS listener should use,
+// requiring and verifying a client certificate against ClientCAFile.
+func (cfg MTLSConfig) TLSConfig() (*tls.Config, error) {
+	pem, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("auth: client CA bundle contains no valid certificates")
+	}
+
+	return &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}, nil
+}
+
+// MTLSPrincipalMiddleware reads the verified client certificate from the
+// TLS connection (set by tls.Config{ClientAuth: RequireAndVerifyClientCert})
+// and attaches a Principal scoped by cfg.Principals, keyed by the
+// certificate's CommonName, so that downstream scope checks work the
+// same way regardless of which auth mechanism authenticated the request.
+//
+// If cfg.Principals is empty, every CA-signed cert is attached as an
+// unscoped Principal -- the same unrestricted access this middleware has
+// always granted, kept only for operators who haven't configured
+// per-tenant scoping yet. Once cfg.Principals is non-empty, a
+// CommonName with no matching entry is left unauthenticated instead of
+// silently falling back to that unrestricted default, so a stray
+// CA-signed cert can't get broader access than every configured tenant.
+func MTLSPrincipalMiddleware(cfg MTLSConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+			c.Next()
+			return
+		}
+
+		cn := c.Request.TLS.PeerCertificates[0].Subject.CommonName
+		scope, ok := cfg.scopeFor(cn)
+		if !ok {
+			if len(cfg.Principals) > 0 {
+				c.Next()
+				return
+			}
+			SetPrincipal(c, &Principal{Name: cn, Source: "mtls"})
+			c.Next()
+			return
+		}
+
+		SetPrincipal(c, &Principal{
+			Name:      cn,
+			Source:    "mtls",
+			Platforms: scope.Platforms,
+			Topics:    scope.Topics,
+		})
+		c.Next()
+	}
+}
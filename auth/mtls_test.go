@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func contextWithClientCert(cn string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/api/push", nil)
+	c.Request.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}},
+	}
+	return c
+}
+
+func TestMTLSPrincipalMiddlewareUnconfiguredAllowsEverything(t *testing.T) {
+	c := contextWithClientCert("tenant-a")
+	MTLSPrincipalMiddleware(MTLSConfig{})(c)
+
+	principal, ok := FromContext(c)
+	if !ok {
+		t.Fatal("expected a principal to be attached")
+	}
+	if !principal.AllowsPlatform("ios") || !principal.AllowsTopic("anything") {
+		t.Error("expected an unconfigured mTLS principal to remain unrestricted")
+	}
+}
+
+func TestMTLSPrincipalMiddlewareScopesConfiguredCommonName(t *testing.T) {
+	cfg := MTLSConfig{Principals: []MTLSPrincipal{
+		{CommonName: "tenant-a", Platforms: []string{"ios"}, Topics: []string{"tenant-a-topic"}},
+	}}
+
+	c := contextWithClientCert("tenant-a")
+	MTLSPrincipalMiddleware(cfg)(c)
+
+	principal, ok := FromContext(c)
+	if !ok {
+		t.Fatal("expected a principal to be attached")
+	}
+	if !principal.AllowsPlatform("ios") || principal.AllowsPlatform("android") {
+		t.Error("expected tenant-a's principal to be scoped to the ios platform only")
+	}
+}
+
+func TestMTLSPrincipalMiddlewareRejectsUnlistedCommonName(t *testing.T) {
+	cfg := MTLSConfig{Principals: []MTLSPrincipal{
+		{CommonName: "tenant-a", Platforms: []string{"ios"}},
+	}}
+
+	c := contextWithClientCert("tenant-b")
+	MTLSPrincipalMiddleware(cfg)(c)
+
+	if _, ok := FromContext(c); ok {
+		t.Error("expected a CommonName with no configured scope to be left unauthenticated once Principals is non-empty")
+	}
+}
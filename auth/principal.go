@@ -0,0 +1,51 @@
+// Package auth implements the optional authentication layer in front of
+// the push API: static bearer tokens, per-app scoped API keys, and
+// mutual TLS. All three mechanisms are independent and may be enabled
+// together; a request is authenticated if any one of them succeeds.
+package auth
+
+// Principal identifies whoever made an authenticated request, along with
+// the platform/topic scopes they are allowed to push to. A nil or empty
+// Platforms/Topics slice means "no restriction" for that dimension.
+type Principal struct {
+	// Name is a human-readable identifier for logs and /api/auth/whoami,
+	// e.g. the API key's label or the mTLS certificate's CN.
+	Name string
+	// Source records which mechanism authenticated the request: "bearer",
+	// "apikey", or "mtls".
+	Source string
+	// Platforms restricts which notify.RequestPush platforms this
+	// principal may target; empty means all platforms are allowed.
+	Platforms []string
+	// Topics restricts which topics/app IDs this principal may target;
+	// empty means all topics are allowed.
+	Topics []string
+}
+
+// AllowsPlatform reports whether p is allowed to push to platform.
+func (p *Principal) AllowsPlatform(platform string) bool {
+	if p == nil || len(p.Platforms) == 0 {
+		return true
+	}
+	for _, allowed := range p.Platforms {
+		if allowed == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTopic reports whether p is allowed to push to topic. An empty
+// topic (a direct-token push with no `to` field) is always allowed,
+// since topic scoping only applies to topic/app-addressed pushes.
+func (p *Principal) AllowsTopic(topic string) bool {
+	if p == nil || topic == "" || len(p.Topics) == 0 {
+		return true
+	}
+	for _, allowed := range p.Topics {
+		if allowed == topic {
+			return true
+		}
+	}
+	return false
+}
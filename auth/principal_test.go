@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPrincipalScopeEnforcement(t *testing.T) {
+	scoped := &Principal{
+		Name:      "tenant-a",
+		Platforms: []string{"ios", "android"},
+		Topics:    []string{"tenant-a-topic"},
+	}
+
+	cases := []struct {
+		name     string
+		platform string
+		topic    string
+		want     bool
+	}{
+		{"allowed platform, no topic", "ios", "", true},
+		{"allowed platform, allowed topic", "android", "tenant-a-topic", true},
+		{"disallowed platform", "huawei", "", false},
+		{"allowed platform, disallowed topic", "ios", "tenant-b-topic", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scoped.AllowsPlatform(tc.platform) && scoped.AllowsTopic(tc.topic)
+			if got != tc.want {
+				t.Errorf("platform=%q topic=%q: got %v, want %v", tc.platform, tc.topic, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrincipalUnscopedAllowsEverything(t *testing.T) {
+	var unscoped *Principal
+
+	if !unscoped.AllowsPlatform("ios") || !unscoped.AllowsTopic("anything") {
+		t.Error("nil principal should allow all platforms and topics")
+	}
+
+	empty := &Principal{Name: "bearer"}
+	if !empty.AllowsPlatform("android") || !empty.AllowsTopic("anything") {
+		t.Error("principal with no configured scopes should allow all platforms and topics")
+	}
+}
+
+func TestStoreAuthenticate(t *testing.T) {
+	store := NewStore()
+	store.Load([]string{"op-token"}, []APIKey{
+		{Key: "tenant-key", Name: "tenant-a", Platforms: []string{"ios"}},
+	})
+
+	if _, ok := store.Authenticate("op-token"); !ok {
+		t.Error("expected bearer token to authenticate")
+	}
+
+	principal, ok := store.Authenticate("tenant-key")
+	if !ok || principal.Name != "tenant-a" {
+		t.Error("expected API key to authenticate as its scoped principal")
+	}
+
+	if _, ok := store.Authenticate("unknown"); ok {
+		t.Error("expected unknown credential to be rejected")
+	}
+}
+
+func TestAPIKeyMarshalJSONRedactsKey(t *testing.T) {
+	key := APIKey{Key: "super-secret", Name: "tenant-a"}
+
+	out, err := json.Marshal(key)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(out), "super-secret") {
+		t.Fatalf("expected marshaled APIKey to redact Key, got %s", out)
+	}
+
+	var roundTripped struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &roundTripped); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if roundTripped.Key != redactedSecret || roundTripped.Name != "tenant-a" {
+		t.Fatalf("unexpected redacted output: %+v", roundTripped)
+	}
+}
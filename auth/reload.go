@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Config is the shape of config.ConfYaml.API.Auth.
+type Config struct {
+	Enabled bool              `yaml:"enabled" json:"enabled"`
+	Bearer  TokenSourceConfig `yaml:"bearer" json:"bearer"`
+	APIKeys []APIKey          `yaml:"api_keys" json:"api_keys"`
+}
+
+// Manager owns a Store and knows how to (re)load it from Config,
+// refreshing on SIGHUP so that rotating a token file doesn't require a
+// restart.
+type Manager struct {
+	store *Store
+	cfg   Config
+}
+
+// NewManager builds a Manager and performs the initial load from cfg.
+func NewManager(cfg Config) (*Manager, error) {
+	m := &Manager{store: NewStore(), cfg: cfg}
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Store returns the Manager's underlying Store for use by Middleware.
+func (m *Manager) Store() *Store {
+	return m.store
+}
+
+func (m *Manager) reload() error {
+	bearerTokens, err := m.cfg.Bearer.Resolve()
+	if err != nil {
+		return err
+	}
+	m.store.Load(bearerTokens, m.cfg.APIKeys)
+	return nil
+}
+
+// WatchSIGHUP installs a signal handler that reloads the bearer token
+// source (file or env) on SIGHUP, logging the outcome. It returns
+// immediately; the watcher runs until the process exits.
+func (m *Manager) WatchSIGHUP() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	go func() {
+		for range sig {
+			if err := m.reload(); err != nil {
+				log.Error().Err(err).Msg("auth: failed to reload token store on SIGHUP")
+				continue
+			}
+			log.Info().Msg("auth: reloaded token store on SIGHUP")
+		}
+	}()
+}
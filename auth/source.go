@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TokenSourceConfig describes where to resolve one or more bearer
+// tokens from. Exactly one of Inline, File, or Env should be set; they
+// are tried in that priority order, mirroring how the flyctl agent
+// resolves an auth token from several candidate sources.
+type TokenSourceConfig struct {
+	// Inline is a literal token value, or a comma-separated list of them.
+	Inline string `yaml:"inline" json:"inline"`
+	// File points at a file containing one token per line; blank lines
+	// and lines starting with "#" are ignored.
+	File string `yaml:"file" json:"file"`
+	// Env names an environment variable holding a literal token, or a
+	// comma-separated list of them.
+	Env string `yaml:"env" json:"env"`
+}
+
+// Resolve returns the tokens described by cfg, trying Inline, then File,
+// then Env, and returning the tokens of the first one that is set. A set
+// Inline or File that yields no tokens is returned as is; the later
+// sources are not tried.
+func (cfg TokenSourceConfig) Resolve() ([]string, error) {
+	if cfg.Inline != "" {
+		return splitTokens(cfg.Inline), nil
+	}
+	if cfg.File != "" {
+		return readTokenFile(cfg.File)
+	}
+	if cfg.Env != "" {
+		if v := os.Getenv(cfg.Env); v != "" {
+			return splitTokens(v), nil
+		}
+	}
+	return nil, nil
+}
+
+func splitTokens(raw string) []string {
+	parts := strings.Split(raw, ",")
+	tokens := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			tokens = append(tokens, p)
+		}
+	}
+	return tokens
+}
+
+func readTokenFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: reading token file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("auth: reading token file %s: %w", path, err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+)
+
+// hash returns the SHA-256 digest of token, so that Store never keeps
+// plaintext tokens around longer than it takes to hash an incoming
+// request's Authorization header.
+func hash(token string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(token))
+}
+
+// Store holds the set of valid bearer tokens and scoped API keys, keyed
+// by their SHA-256 digest and matched against the digest of incoming
+// credentials. It is safe for concurrent use and can be swapped out
+// wholesale (see Load) so
+// that SIGHUP can pick up a rotated token file without restarting.
+type Store struct {
+	mu      sync.RWMutex
+	bearer  map[[sha256.Size]byte]struct{}
+	apiKeys map[[sha256.Size]byte]*Principal
+}
+
+// NewStore builds an empty Store.
+func NewStore() *Store {
+	return &Store{
+		bearer:  make(map[[sha256.Size]byte]struct{}),
+		apiKeys: make(map[[sha256.Size]byte]*Principal),
+	}
+}
+
+// APIKey is one entry of the per-app API key list, as loaded from
+// config.ConfYaml.API.Auth.APIKeys.
+type APIKey struct {
+	Key       string   `yaml:"key" json:"key"`
+	Name      string   `yaml:"name" json:"name"`
+	Platforms []string `yaml:"platforms" json:"platforms"`
+	Topics    []string `yaml:"topics" json:"topics"`
+}
+
+// apiKeyAlias has the same fields as APIKey but none of its methods, so
+// marshaling an apiKeyAlias can't recurse back into APIKey's own
+// MarshalJSON/MarshalYAML.
+type apiKeyAlias APIKey
+
+const redactedSecret = "***"
+
+// MarshalJSON redacts Key so that serializing an APIKey -- e.g. the
+// config tree served by GET cfg.API.ConfigURI -- never leaks the
+// plaintext key alongside it.
+func (k APIKey) MarshalJSON() ([]byte, error) {
+	redacted := apiKeyAlias(k)
+	if redacted.Key != "" {
+		redacted.Key = redactedSecret
+	}
+	return json.Marshal(redacted)
+}
+
+// MarshalYAML redacts Key for the same reason as MarshalJSON.
+func (k APIKey) MarshalYAML() (interface{}, error) {
+	redacted := apiKeyAlias(k)
+	if redacted.Key != "" {
+		redacted.Key = redactedSecret
+	}
+	return redacted, nil
+}
+
+// Load replaces the Store's contents with bearerTokens and apiKeys. It
+// is safe to call while the Store is serving requests; readers either
+// see the old or the new generation, never a partial one.
+func (s *Store) Load(bearerTokens []string, apiKeys []APIKey) {
+	bearer := make(map[[sha256.Size]byte]struct{}, len(bearerTokens))
+	for _, t := range bearerTokens {
+		if t == "" {
+			continue
+		}
+		bearer[hash(t)] = struct{}{}
+	}
+
+	keys := make(map[[sha256.Size]byte]*Principal, len(apiKeys))
+	for _, k := range apiKeys {
+		if k.Key == "" {
+			continue
+		}
+		keys[hash(k.Key)] = &Principal{
+			Name:      k.Name,
+			Source:    "apikey",
+			Platforms: k.Platforms,
+			Topics:    k.Topics,
+		}
+	}
+
+	s.mu.Lock()
+	s.bearer = bearer
+	s.apiKeys = keys
+	s.mu.Unlock()
+}
+
+// Authenticate looks up token against both the bearer-token set and the
+// API key set, returning the matched Principal. Plain bearer tokens
+// authenticate as an unscoped Principal since they predate per-app
+// scoping and are meant for operators, not tenants.
+func (s *Store) Authenticate(token string) (*Principal, bool) {
+	if token == "" {
+		return nil, false
+	}
+	digest := hash(token)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if p, ok := s.apiKeys[digest]; ok {
+		return p, true
+	}
+	if _, ok := s.bearer[digest]; ok {
+		return &Principal{Name: "bearer", Source: "bearer"}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WhoAmIHandler returns the Principal matched for this request, for
+// operators debugging why a token was or wasn't accepted.
+func WhoAmIHandler(c *gin.Context) {
+	principal, ok := FromContext(c)
+	if !ok {
+		c.JSON(http.StatusOK, gin.H{"authenticated": false})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"authenticated": true,
+		"name":          principal.Name,
+		"source":        principal.Source,
+		"platforms":     principal.Platforms,
+		"topics":        principal.Topics,
+	})
+}
@@ -0,0 +1,109 @@
+// Package config defines ConfYaml, the configuration tree gorush loads
+// from a YAML file. Subsystems that have their own construction logic
+// (auth, tokenlc, webhooks, jobs) each define their own Config type in
+// their own package; ConfYaml only embeds them under the section
+// operators expect to find them in, so this package stays a plain data
+// tree with no behavior of its own.
+package config
+
+import (
+	"time"
+
+	"github.com/tolki-app/gorush/auth"
+	"github.com/tolki-app/gorush/jobs"
+	"github.com/tolki-app/gorush/tokenlc"
+	"github.com/tolki-app/gorush/webhooks"
+)
+
+// ConfYaml is the root of gorush's configuration.
+type ConfYaml struct {
+	Core     SectionCore           `yaml:"core" json:"core"`
+	API      SectionAPI            `yaml:"api" json:"api"`
+	Log      SectionLog            `yaml:"log" json:"log"`
+	Queue    SectionQueue          `yaml:"queue" json:"queue"`
+	Ios      PlatformConfig        `yaml:"ios" json:"ios"`
+	Android  PlatformConfig        `yaml:"android" json:"android"`
+	Huawei   PlatformConfig        `yaml:"huawei" json:"huawei"`
+	Cleaner  tokenlc.CleanerConfig `yaml:"cleaner" json:"cleaner"`
+	Webhooks webhooks.Config       `yaml:"webhooks" json:"webhooks"`
+	Jobs     jobs.Config           `yaml:"jobs" json:"jobs"`
+}
+
+// SectionCore holds process-wide settings that aren't specific to any
+// platform or API route.
+type SectionCore struct {
+	// Mode is gin's run mode: "debug", "release", or "test".
+	Mode string `yaml:"mode" json:"mode"`
+	// Sync sends notifications inline on the request goroutine instead
+	// of only handing them to the queue; only honored for in-process
+	// queue engines (see core.IsLocalQueue).
+	Sync bool `yaml:"sync" json:"sync"`
+	// MaxNotification caps how many notifications a single push request
+	// may submit.
+	MaxNotification int64 `yaml:"max_notification" json:"max_notification"`
+	// AdminAddress binds the admin listener (health/metrics/stat/config);
+	// "" defaults to "127.0.0.1:0", a random free port.
+	AdminAddress string `yaml:"admin_address" json:"admin_address"`
+	// AutoTLS configures the optional Let's Encrypt listener.
+	AutoTLS SectionAutoTLS `yaml:"auto_tls" json:"auto_tls"`
+	// ReadHeaderTimeout, ReadTimeout, WriteTimeout, and IdleTimeout are
+	// applied to every http.Server gorush starts, in place of Go's
+	// no-timeout defaults.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" json:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" json:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" json:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
+}
+
+// SectionAutoTLS is cfg.Core.AutoTLS.
+type SectionAutoTLS struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Host    string `yaml:"host" json:"host"`
+	Folder  string `yaml:"folder" json:"folder"`
+}
+
+// SectionAPI holds route paths and the optional authentication layers
+// in front of them.
+type SectionAPI struct {
+	PushURI    string `yaml:"push_uri" json:"push_uri"`
+	HealthURI  string `yaml:"health_uri" json:"health_uri"`
+	MetricURI  string `yaml:"metric_uri" json:"metric_uri"`
+	StatGoURI  string `yaml:"stat_go_uri" json:"stat_go_uri"`
+	StatAppURI string `yaml:"stat_app_uri" json:"stat_app_uri"`
+	ConfigURI  string `yaml:"config_uri" json:"config_uri"`
+	SysStatURI string `yaml:"sys_stat_uri" json:"sys_stat_uri"`
+	JobURI     string `yaml:"job_uri" json:"job_uri"`
+	// Auth configures bearer/API-key authentication for PushURI and JobURI.
+	Auth auth.Config `yaml:"auth" json:"auth"`
+	// MTLS configures the parallel mutual-TLS listener.
+	MTLS auth.MTLSConfig `yaml:"mtls" json:"mtls"`
+	// Timeouts gives each timeout-sensitive route its own deadline.
+	Timeouts TimeoutsConfig `yaml:"timeouts" json:"timeouts"`
+}
+
+// TimeoutsConfig is cfg.API.Timeouts. A zero value disables the timeout
+// for that route. It lives here, not in package router, because router
+// already imports config for *ConfYaml; defining it there instead would
+// be an import cycle.
+type TimeoutsConfig struct {
+	// Push bounds POST cfg.API.PushURI (both sync and async mode).
+	Push time.Duration `yaml:"push" json:"push"`
+}
+
+// SectionLog is cfg.Log.
+type SectionLog struct {
+	Format    string `yaml:"format" json:"format"`
+	HideToken bool   `yaml:"hide_token" json:"hide_token"`
+}
+
+// SectionQueue is cfg.Queue.
+type SectionQueue struct {
+	// Engine selects the notification queue backend: "local" (the
+	// in-process default), "redis", "nsq", etc.
+	Engine string `yaml:"engine" json:"engine"`
+}
+
+// PlatformConfig is cfg.Ios / cfg.Android / cfg.Huawei.
+type PlatformConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFileEnv names the environment variable holding the path to a YAML
+// file to load on top of Default(). It is optional; LoadConf returns
+// Default() unchanged when it is unset.
+const ConfigFileEnv = "GORUSH_CONFIG"
+
+// Default returns the configuration gorush runs with when no config file
+// is supplied: local queue, in-memory job store, auth/mTLS/webhooks all
+// disabled, and the usual fixed API routes.
+func Default() *ConfYaml {
+	return &ConfYaml{
+		Core: SectionCore{
+			Mode:            "release",
+			MaxNotification: 100,
+			AdminAddress:    "127.0.0.1:0",
+		},
+		API: SectionAPI{
+			PushURI:    "/api/push",
+			HealthURI:  "/api/health",
+			MetricURI:  "/metrics",
+			StatGoURI:  "/api/stat/go",
+			StatAppURI: "/api/stat/app",
+			ConfigURI:  "/api/config",
+			SysStatURI: "/sys/stats",
+			JobURI:     "/api/jobs",
+		},
+		Queue: SectionQueue{
+			Engine: "local",
+		},
+	}
+}
+
+// LoadConf returns Default(), merged with the YAML file named by
+// ConfigFileEnv if that variable is set. A missing or invalid file is
+// reported as an error rather than silently falling back to defaults, so
+// a typo in the path doesn't run with unintended configuration.
+func LoadConf() (*ConfYaml, error) {
+	cfg := Default()
+
+	path := os.Getenv(ConfigFileEnv)
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,128 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var jobsBucket = []byte("jobs")
+
+// BoltStore persists Jobs in a BoltDB bucket, for operators who want
+// job status to survive a process restart without standing up Redis.
+// Unlike RedisStore, expired jobs are only reaped lazily on Get, since
+// BoltDB has no native key TTL.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) the jobs bucket in db.
+func NewBoltStore(db *bolt.DB) (*BoltStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Create implements JobStore.
+func (s *BoltStore) Create(_ context.Context, owner string, total int, ttl int64) (*Job, error) {
+	job := &Job{
+		ID:        NewID(),
+		State:     StateQueued,
+		Counts:    Counts{Total: total},
+		Owner:     owner,
+		CreatedAt: time.Now(),
+		TTL:       time.Duration(ttl) * time.Second,
+	}
+	if err := s.save(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get implements JobStore.
+func (s *BoltStore) Get(_ context.Context, id string) (*Job, error) {
+	var job Job
+	found := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(jobsBucket).Get([]byte(id))
+		if raw == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(raw, &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrNotFound
+	}
+	if job.TTL > 0 && !job.FinishedAt.IsZero() && time.Since(job.FinishedAt) > job.TTL {
+		_ = s.delete(id)
+		return nil, ErrNotFound
+	}
+
+	return &job, nil
+}
+
+// Update implements JobStore. The read, mutate, and write all happen
+// inside a single bolt write transaction so concurrent Update calls for
+// the same id (e.g. one goroutine per notification in a batch, each
+// recording its own token result) serialize instead of racing: bolt
+// holds one writer at a time, so the transaction that runs second
+// always sees the first one's write.
+func (s *BoltStore) Update(_ context.Context, id string, mutate func(job *Job)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+		raw := bucket.Get([]byte(id))
+		if raw == nil {
+			return ErrNotFound
+		}
+
+		var job Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			return err
+		}
+		mutate(&job)
+
+		out, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(job.ID), out)
+	})
+}
+
+// Cancel implements JobStore.
+func (s *BoltStore) Cancel(ctx context.Context, id string) error {
+	return s.Update(ctx, id, func(job *Job) {
+		if !job.Done() {
+			job.State = StateCancelled
+			job.FinishedAt = time.Now()
+		}
+	})
+}
+
+func (s *BoltStore) save(job *Job) error {
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(job.ID), raw)
+	})
+}
+
+func (s *BoltStore) delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Delete([]byte(id))
+	})
+}
@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "jobs.db"), 0o600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := NewBoltStore(db)
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	return store
+}
+
+func TestBoltStoreLifecycle(t *testing.T) {
+	store := openTestBoltStore(t)
+	ctx := context.Background()
+
+	job, err := store.Create(ctx, "tenant-a", 3, 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if job.Owner != "tenant-a" {
+		t.Fatalf("expected job to record its creator as owner, got %q", job.Owner)
+	}
+
+	if err := store.Update(ctx, job.ID, func(j *Job) {
+		j.Counts.Success = 3
+		j.State = StateSucceeded
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := store.Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.State != StateSucceeded || got.Counts.Success != 3 {
+		t.Fatalf("unexpected job after update: %+v", got)
+	}
+}
+
+// TestBoltStoreUpdateSerializesConcurrentWrites fires one Update per
+// token result, concurrently, against the same job -- mirroring
+// handleNotificationAsync's one-goroutine-per-notification pattern --
+// and checks every increment landed. A read-then-write Update without a
+// transaction around both halves loses increments here.
+func TestBoltStoreUpdateSerializesConcurrentWrites(t *testing.T) {
+	store := openTestBoltStore(t)
+	ctx := context.Background()
+
+	const n = 100
+	job, err := store.Create(ctx, "", n, 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := store.Update(ctx, job.ID, func(j *Job) {
+				j.Counts.Success++
+			})
+			if err != nil {
+				t.Errorf("Update: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := store.Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Counts.Success != n {
+		t.Fatalf("expected %d successes recorded, got %d (lost updates)", n, got.Counts.Success)
+	}
+}
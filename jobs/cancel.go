@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+)
+
+// CancelRegistry tracks the context.CancelFunc for each in-flight async
+// job in this process, so that DELETE /api/jobs/:id can cancel queued
+// work the same way a client disconnect already cancels a synchronous
+// push (see router.pushHandler): by cancelling the context that was
+// threaded into notify.SendNotification.
+//
+// A JobStore tracks job state, which may be backed by Redis or BoltDB
+// and shared across processes; CancelRegistry is always process-local,
+// since only the process actually running the send can cancel it.
+type CancelRegistry struct {
+	mu     sync.Mutex
+	cancel map[string]context.CancelFunc
+}
+
+// NewCancelRegistry returns an empty CancelRegistry.
+func NewCancelRegistry() *CancelRegistry {
+	return &CancelRegistry{cancel: make(map[string]context.CancelFunc)}
+}
+
+// Register derives a cancellable context from parent for id and stores
+// its CancelFunc. Callers must call Forget(id) once the job finishes to
+// avoid leaking the entry.
+func (r *CancelRegistry) Register(parent context.Context, id string) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+
+	r.mu.Lock()
+	r.cancel[id] = cancel
+	r.mu.Unlock()
+
+	return ctx
+}
+
+// Cancel cancels the context registered for id, returning false if no
+// such job is currently tracked (already finished, or unknown).
+func (r *CancelRegistry) Cancel(id string) bool {
+	r.mu.Lock()
+	cancel, ok := r.cancel[id]
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	cancel()
+	return true
+}
+
+// Forget removes id from the registry once its job has reached a
+// terminal state.
+func (r *CancelRegistry) Forget(id string) {
+	r.mu.Lock()
+	delete(r.cancel, id)
+	r.mu.Unlock()
+}
@@ -0,0 +1,29 @@
+package jobs
+
+// Config is the shape of config.ConfYaml.Jobs, selecting which JobStore
+// backs the async push API.
+type Config struct {
+	// Engine selects the JobStore implementation: "memory" (default),
+	// "redis", or "bolt".
+	Engine string `yaml:"engine" json:"engine"`
+	// MemoryCapacity bounds MemoryStore; 0 uses defaultMemoryCapacity.
+	MemoryCapacity int `yaml:"memory_capacity" json:"memory_capacity"`
+	// TTLSeconds is how long a finished job is kept before it is
+	// eligible for eviction; 0 means "keep until evicted for capacity".
+	TTLSeconds int64       `yaml:"ttl_seconds" json:"ttl_seconds"`
+	Redis      RedisConfig `yaml:"redis" json:"redis"`
+	Bolt       BoltConfig  `yaml:"bolt" json:"bolt"`
+}
+
+// RedisConfig is cfg.Jobs.Redis, used when Engine is "redis".
+type RedisConfig struct {
+	Addr string `yaml:"addr" json:"addr"`
+	// Prefix namespaces job keys; "" uses defaultRedisKeyPrefix.
+	Prefix string `yaml:"prefix" json:"prefix"`
+}
+
+// BoltConfig is cfg.Jobs.Bolt, used when Engine is "bolt".
+type BoltConfig struct {
+	// Path is the BoltDB file to open (created if it doesn't exist).
+	Path string `yaml:"path" json:"path"`
+}
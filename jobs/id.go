@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// idEntropy is shared across NewID calls, guarded by idMu, since
+// ulid.Monotonic is not safe for concurrent use on its own.
+var (
+	idMu      sync.Mutex
+	idEntropy = ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
+)
+
+// NewID returns a new, lexicographically-sortable job identifier.
+func NewID() string {
+	idMu.Lock()
+	defer idMu.Unlock()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), idEntropy).String()
+}
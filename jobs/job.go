@@ -0,0 +1,61 @@
+// Package jobs tracks asynchronously-submitted push batches so a client
+// can submit a large batch, disconnect, and later poll for the outcome
+// instead of holding an HTTP connection open for the duration of the
+// send.
+package jobs
+
+import "time"
+
+// State is the lifecycle stage of a Job.
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StatePartial   State = "partial"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// TokenResult is the outcome for a single token within a Job.
+type TokenResult struct {
+	Token    string `json:"token"`
+	Platform string `json:"platform"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Counts summarizes a Job's TokenResults without requiring a client to
+// walk the full result list.
+type Counts struct {
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Failure int `json:"failure"`
+}
+
+// Job is the full record tracked for one async push submission.
+type Job struct {
+	ID     string `json:"job_id"`
+	State  State  `json:"state"`
+	Counts Counts `json:"counts"`
+	// Owner is the authenticating principal's name at creation time, or
+	// "" when auth is disabled. It is never returned to clients; it only
+	// gates access (see router.authorizeJobAccess), so it stays
+	// unexported from the wire format the same way TTL does.
+	Owner      string        `json:"-"`
+	Results    []TokenResult `json:"results,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	FinishedAt time.Time     `json:"finished_at,omitempty"`
+	TTL        time.Duration `json:"-"`
+}
+
+// Done reports whether Job has reached a terminal state.
+func (j *Job) Done() bool {
+	switch j.State {
+	case StateSucceeded, StatePartial, StateFailed, StateCancelled:
+		return true
+	default:
+		return false
+	}
+}
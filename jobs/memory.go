@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMemoryCapacity = 10000
+
+// MemoryStore is the default JobStore: an in-memory LRU keyed by job ID.
+// It is appropriate for a single gorush instance; multi-instance
+// deployments should use RedisStore or BoltStore so a status poll can
+// land on any instance.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	jobs     map[string]*list.Element
+	order    *list.List // front = most recently used
+}
+
+type memoryEntry struct {
+	id  string
+	job *Job
+}
+
+// NewMemoryStore builds a MemoryStore holding at most capacity jobs,
+// evicting the least recently used entry once full. capacity <= 0 uses
+// defaultMemoryCapacity.
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = defaultMemoryCapacity
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		jobs:     make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Create implements JobStore.
+func (s *MemoryStore) Create(_ context.Context, owner string, total int, ttl int64) (*Job, error) {
+	job := &Job{
+		ID:        NewID(),
+		State:     StateQueued,
+		Counts:    Counts{Total: total},
+		Owner:     owner,
+		CreatedAt: time.Now(),
+		TTL:       time.Duration(ttl) * time.Second,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpiredLocked()
+	if s.order.Len() >= s.capacity {
+		s.evictOldestLocked()
+	}
+
+	elem := s.order.PushFront(&memoryEntry{id: job.ID, job: job})
+	s.jobs[job.ID] = elem
+
+	return job, nil
+}
+
+// Get implements JobStore.
+func (s *MemoryStore) Get(_ context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if s.expiredLocked(entry.job) {
+		s.removeLocked(elem)
+		return nil, ErrNotFound
+	}
+
+	s.order.MoveToFront(elem)
+	jobCopy := *entry.job
+	return &jobCopy, nil
+}
+
+// Update implements JobStore.
+func (s *MemoryStore) Update(_ context.Context, id string, mutate func(job *Job)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	mutate(entry.job)
+	s.order.MoveToFront(elem)
+	return nil
+}
+
+// Cancel implements JobStore.
+func (s *MemoryStore) Cancel(ctx context.Context, id string) error {
+	return s.Update(ctx, id, func(job *Job) {
+		if !job.Done() {
+			job.State = StateCancelled
+			job.FinishedAt = time.Now()
+		}
+	})
+}
+
+func (s *MemoryStore) expiredLocked(job *Job) bool {
+	if job.TTL <= 0 || job.FinishedAt.IsZero() {
+		return false
+	}
+	return time.Since(job.FinishedAt) > job.TTL
+}
+
+func (s *MemoryStore) evictOldestLocked() {
+	oldest := s.order.Back()
+	if oldest != nil {
+		s.removeLocked(oldest)
+	}
+}
+
+func (s *MemoryStore) evictExpiredLocked() {
+	for elem := s.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		entry := elem.Value.(*memoryEntry)
+		if s.expiredLocked(entry.job) {
+			s.removeLocked(elem)
+		}
+		elem = prev
+	}
+}
+
+func (s *MemoryStore) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*memoryEntry)
+	delete(s.jobs, entry.id)
+	s.order.Remove(elem)
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreLifecycle(t *testing.T) {
+	store := NewMemoryStore(10)
+	ctx := context.Background()
+
+	job, err := store.Create(ctx, "", 3, 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if job.State != StateQueued {
+		t.Fatalf("expected new job to be queued, got %s", job.State)
+	}
+
+	if err := store.Update(ctx, job.ID, func(j *Job) {
+		j.Counts.Success = 3
+		j.State = StateSucceeded
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := store.Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.State != StateSucceeded || got.Counts.Success != 3 {
+		t.Fatalf("unexpected job after update: %+v", got)
+	}
+
+	if _, err := store.Get(ctx, "does-not-exist"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStoreCreateRecordsOwner(t *testing.T) {
+	store := NewMemoryStore(10)
+	ctx := context.Background()
+
+	job, err := store.Create(ctx, "tenant-a", 1, 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if job.Owner != "tenant-a" {
+		t.Fatalf("expected job to record its creator as owner, got %q", job.Owner)
+	}
+}
+
+func TestMemoryStoreEvictsOldestBeyondCapacity(t *testing.T) {
+	store := NewMemoryStore(2)
+	ctx := context.Background()
+
+	first, _ := store.Create(ctx, "", 1, 0)
+	_, _ = store.Create(ctx, "", 1, 0)
+	_, _ = store.Create(ctx, "", 1, 0)
+
+	if _, err := store.Get(ctx, first.ID); err != ErrNotFound {
+		t.Fatalf("expected the oldest job to be evicted once capacity was exceeded")
+	}
+}
+
+func TestCancelRegistry(t *testing.T) {
+	reg := NewCancelRegistry()
+	ctx := reg.Register(context.Background(), "job-1")
+
+	if !reg.Cancel("job-1") {
+		t.Fatal("expected Cancel to find the registered job")
+	}
+	if ctx.Err() == nil {
+		t.Fatal("expected the derived context to be cancelled")
+	}
+
+	reg.Forget("job-1")
+	if reg.Cancel("job-1") {
+		t.Fatal("expected Cancel to report nothing registered after Forget")
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	jobsInflight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gorush_jobs_inflight",
+		Help: "Number of async push jobs currently queued or running.",
+	})
+
+	jobDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "gorush_job_duration_seconds",
+		Help:    "Time from an async push job being created to reaching a terminal state.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(jobsInflight, jobDuration)
+}
+
+// ObserveStart records a newly created job entering the inflight gauge.
+func ObserveStart() {
+	jobsInflight.Inc()
+}
+
+// ObserveFinish records a job leaving the inflight gauge and reports its
+// total duration.
+func ObserveFinish(createdAt time.Time) {
+	jobsInflight.Dec()
+	jobDuration.Observe(time.Since(createdAt).Seconds())
+}
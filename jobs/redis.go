@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisKeyPrefix = "gorush:jobs:"
+
+// RedisStore persists Jobs as JSON blobs in Redis, keyed by
+// "<prefix><job id>" with a TTL so completed jobs expire automatically.
+// Use this over MemoryStore when status polls may land on any of
+// several gorush instances behind a load balancer.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore. An empty prefix uses
+// defaultRedisKeyPrefix.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = defaultRedisKeyPrefix
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) key(id string) string {
+	return s.prefix + id
+}
+
+// Create implements JobStore.
+func (s *RedisStore) Create(ctx context.Context, owner string, total int, ttl int64) (*Job, error) {
+	job := &Job{
+		ID:        NewID(),
+		State:     StateQueued,
+		Counts:    Counts{Total: total},
+		Owner:     owner,
+		CreatedAt: time.Now(),
+		TTL:       time.Duration(ttl) * time.Second,
+	}
+	if err := s.save(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get implements JobStore.
+func (s *RedisStore) Get(ctx context.Context, id string) (*Job, error) {
+	raw, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	if err := json.Unmarshal(raw, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update implements JobStore. It uses WATCH/MULTI so concurrent
+// Update calls for the same id (e.g. one goroutine per notification in
+// a batch, each recording its own token result) never clobber each
+// other: if another client writes the key between the read and the
+// write, redis.TxFailedErr is returned and the read-mutate-write is
+// retried against the fresh value.
+func (s *RedisStore) Update(ctx context.Context, id string, mutate func(job *Job)) error {
+	key := s.key(id)
+	for {
+		err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+			raw, err := tx.Get(ctx, key).Bytes()
+			if err == redis.Nil {
+				return ErrNotFound
+			}
+			if err != nil {
+				return err
+			}
+
+			var job Job
+			if err := json.Unmarshal(raw, &job); err != nil {
+				return err
+			}
+			mutate(&job)
+
+			raw, err = json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			expiry := redis.KeepTTL
+			if job.TTL > 0 {
+				expiry = job.TTL
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, raw, expiry)
+				return nil
+			})
+			return err
+		}, key)
+
+		if err == redis.TxFailedErr {
+			continue
+		}
+		return err
+	}
+}
+
+// Cancel implements JobStore.
+func (s *RedisStore) Cancel(ctx context.Context, id string) error {
+	return s.Update(ctx, id, func(job *Job) {
+		if !job.Done() {
+			job.State = StateCancelled
+			job.FinishedAt = time.Now()
+		}
+	})
+}
+
+func (s *RedisStore) save(ctx context.Context, job *Job) error {
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	expiry := redis.KeepTTL
+	if job.TTL > 0 {
+		expiry = job.TTL
+	}
+	return s.client.Set(ctx, s.key(job.ID), raw, expiry).Err()
+}
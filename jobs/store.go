@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by JobStore.Get when no job with that ID
+// exists (including when it existed but its TTL expired).
+var ErrNotFound = errors.New("jobs: job not found")
+
+// JobStore persists Jobs and their progress. Implementations must be
+// safe for concurrent use, since a push batch updates its Job from
+// multiple worker goroutines as tokens complete.
+type JobStore interface {
+	// Create stores a new Job in StateQueued and returns it. owner is the
+	// authenticating principal's name, or "" when auth is disabled.
+	Create(ctx context.Context, owner string, total int, ttl int64) (*Job, error)
+	// Get returns the Job with id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*Job, error)
+	// Update atomically applies mutate to the stored Job and persists
+	// the result. mutate must not retain job beyond the call.
+	Update(ctx context.Context, id string, mutate func(job *Job)) error
+	// Cancel transitions a queued/running job to StateCancelled so that
+	// in-flight sends can observe it and stop. It is a no-op (returns
+	// nil) if the job is already in a terminal state.
+	Cancel(ctx context.Context, id string) error
+}
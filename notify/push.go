@@ -0,0 +1,33 @@
+package notify
+
+// RequestPush and PushNotification model the push API's request body.
+// The full shape (title, sound, priority, and the rest of the
+// provider-specific payload fields) lives outside this series; this
+// file only adds the subset of fields the router package in this
+// series actually references, plus CallbackURL/CallbackEvents, which
+// router/server.go's dispatchDeliveryReceipts reads to resolve a
+// per-notification webhook destination.
+
+// RequestPush is the top-level POST body for cfg.API.PushURI.
+type RequestPush struct {
+	Notifications []PushNotification `json:"notifications" binding:"required"`
+}
+
+// PushNotification is one entry in RequestPush.Notifications.
+type PushNotification struct {
+	ID       string   `json:"id,omitempty"`
+	Tokens   []string `json:"tokens"`
+	Platform int      `json:"platform"`
+	Message  string   `json:"message"`
+	To       string   `json:"to,omitempty"`
+
+	// CallbackURL, CallbackSecret, and CallbackEvents let a single
+	// notification override cfg.Webhooks.Default: when CallbackURL is
+	// set, delivery receipts for this notification go there instead,
+	// signed with CallbackSecret if set (otherwise cfg.Webhooks.Default's
+	// secret), restricted to CallbackEvents if non-empty (see
+	// webhooks.Config.ResolveSpec).
+	CallbackURL    string   `json:"callback_url,omitempty"`
+	CallbackSecret string   `json:"callback_secret,omitempty"`
+	CallbackEvents []string `json:"callback_events,omitempty"`
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"strings"
+
+	"github.com/tolki-app/gorush/core"
+	"github.com/tolki-app/gorush/tokenlc"
+)
+
+// huaweiTerminalCodes maps the Huawei push error codes that mean the
+// token is permanently invalid to a tokenlc.Reason. Huawei reports
+// errors as numeric strings rather than the symbolic names APNs/FCM use.
+var huaweiTerminalCodes = map[string]tokenlc.Reason{
+	"80200003": tokenlc.ReasonUnregistered,
+	"80300002": tokenlc.ReasonBadToken,
+	"80300007": tokenlc.ReasonTokenNotForTopic,
+}
+
+// ClassifyError maps a push-provider error into a stable tokenlc.Reason
+// so that callers can branch on Reason instead of matching against raw
+// provider error text, which is not a stable API across SDK versions.
+// It returns tokenlc.ReasonUnknown when err does not match any known
+// terminal condition.
+func ClassifyError(platform int, err error) tokenlc.Reason {
+	if err == nil {
+		return tokenlc.ReasonUnknown
+	}
+
+	msg := err.Error()
+
+	switch platform {
+	case core.PlatFormIos:
+		return classifyAPNsError(msg)
+	case core.PlatFormAndroid:
+		return classifyFCMError(msg)
+	case core.PlatFormHuawei:
+		return classifyHuaweiError(msg)
+	default:
+		return tokenlc.ReasonUnknown
+	}
+}
+
+func classifyAPNsError(msg string) tokenlc.Reason {
+	switch {
+	case strings.Contains(msg, "Unregistered"):
+		return tokenlc.ReasonUnregistered
+	case strings.Contains(msg, "BadDeviceToken"):
+		return tokenlc.ReasonBadToken
+	case strings.Contains(msg, "DeviceTokenNotForTopic"):
+		return tokenlc.ReasonTokenNotForTopic
+	case strings.Contains(msg, "ExpiredProviderToken"):
+		return tokenlc.ReasonExpiredCredentials
+	default:
+		return tokenlc.ReasonUnknown
+	}
+}
+
+func classifyFCMError(msg string) tokenlc.Reason {
+	switch {
+	case strings.Contains(msg, "UNREGISTERED"):
+		return tokenlc.ReasonUnregistered
+	case strings.Contains(msg, "INVALID_ARGUMENT"):
+		return tokenlc.ReasonBadToken
+	case strings.Contains(msg, "SENDER_ID_MISMATCH"):
+		return tokenlc.ReasonMismatchedSender
+	default:
+		return tokenlc.ReasonUnknown
+	}
+}
+
+func classifyHuaweiError(msg string) tokenlc.Reason {
+	for code, reason := range huaweiTerminalCodes {
+		if strings.Contains(msg, code) {
+			return reason
+		}
+	}
+	return tokenlc.ReasonUnknown
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tolki-app/gorush/core"
+	"github.com/tolki-app/gorush/tokenlc"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform int
+		err      error
+		want     tokenlc.Reason
+	}{
+		{"nil error", core.PlatFormIos, nil, tokenlc.ReasonUnknown},
+		{"apns unregistered", core.PlatFormIos, errors.New("Unregistered"), tokenlc.ReasonUnregistered},
+		{"apns bad token", core.PlatFormIos, errors.New("BadDeviceToken"), tokenlc.ReasonBadToken},
+		{"apns wrong topic", core.PlatFormIos, errors.New("DeviceTokenNotForTopic"), tokenlc.ReasonTokenNotForTopic},
+		{"apns expired provider token", core.PlatFormIos, errors.New("ExpiredProviderToken"), tokenlc.ReasonExpiredCredentials},
+		{"apns unknown", core.PlatFormIos, errors.New("InternalServerError"), tokenlc.ReasonUnknown},
+		{"fcm unregistered", core.PlatFormAndroid, errors.New("UNREGISTERED"), tokenlc.ReasonUnregistered},
+		{"fcm invalid argument", core.PlatFormAndroid, errors.New("INVALID_ARGUMENT"), tokenlc.ReasonBadToken},
+		{"fcm sender mismatch", core.PlatFormAndroid, errors.New("SENDER_ID_MISMATCH"), tokenlc.ReasonMismatchedSender},
+		{"huawei unregistered", core.PlatFormHuawei, errors.New("code: 80200003"), tokenlc.ReasonUnregistered},
+		{"huawei bad token", core.PlatFormHuawei, errors.New("code: 80300002"), tokenlc.ReasonBadToken},
+		{"huawei unknown", core.PlatFormHuawei, errors.New("code: 00000000"), tokenlc.ReasonUnknown},
+		{"unknown platform", 99, errors.New("Unregistered"), tokenlc.ReasonUnknown},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ClassifyError(tc.platform, tc.err)
+			if got != tc.want {
+				t.Errorf("ClassifyError(%d, %v) = %q, want %q", tc.platform, tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-queue/queue"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tolki-app/gorush/auth"
+	"github.com/tolki-app/gorush/config"
+	"github.com/tolki-app/gorush/jobs"
+	"github.com/tolki-app/gorush/notify"
+)
+
+// jobStore backs the async push API. It defaults to an in-memory LRU and
+// is swapped for jobs.RedisStore/jobs.BoltStore when cfg.Jobs.Engine
+// requests it (see newJobStore).
+var jobStore jobs.JobStore
+
+// jobCancels maps an in-flight job ID to the context.CancelFunc that
+// will stop its workers, mirroring the cancellation the sync push path
+// already gets from the client's request context.
+var jobCancels = jobs.NewCancelRegistry()
+
+func newJobStore(cfg *config.ConfYaml) jobs.JobStore {
+	switch cfg.Jobs.Engine {
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.Jobs.Redis.Addr})
+		return jobs.NewRedisStore(client, cfg.Jobs.Redis.Prefix)
+	case "bolt":
+		db, err := bolt.Open(cfg.Jobs.Bolt.Path, 0o600, nil)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to open bolt job store, falling back to in-memory")
+			return jobs.NewMemoryStore(cfg.Jobs.MemoryCapacity)
+		}
+		store, err := jobs.NewBoltStore(db)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to initialize bolt job store, falling back to in-memory")
+			return jobs.NewMemoryStore(cfg.Jobs.MemoryCapacity)
+		}
+		return store
+	default:
+		return jobs.NewMemoryStore(cfg.Jobs.MemoryCapacity)
+	}
+}
+
+// asyncPushHandler implements POST /api/push?mode=async: it validates
+// the request exactly like pushHandler, then enqueues the work and
+// returns 202 Accepted with a job_id instead of waiting for the send to
+// finish.
+func asyncPushHandler(cfg *config.ConfYaml, q *queue.Queue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		form, ok := bindPushRequest(c, cfg)
+		if !ok {
+			return
+		}
+
+		total := 0
+		for _, n := range form.Notifications {
+			total += len(n.Tokens)
+		}
+
+		job, err := jobStore.Create(c.Request.Context(), jobOwner(c), total, cfg.Jobs.TTLSeconds)
+		if err != nil {
+			abortWithError(c, http.StatusInternalServerError, "failed to create job")
+			return
+		}
+		jobs.ObserveStart()
+
+		ctx := jobCancels.Register(context.Background(), job.ID)
+		go handleNotificationAsync(ctx, cfg, form, q, job.ID)
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"job_id":   job.ID,
+			"accepted": total,
+			"links": gin.H{
+				"status": cfg.API.JobURI + "/" + job.ID,
+				"logs":   cfg.API.JobURI + "/" + job.ID + "/logs",
+			},
+		})
+	}
+}
+
+// jobOwner returns the authenticating principal's name, or "" when auth
+// is disabled (no Principal attached to c).
+func jobOwner(c *gin.Context) string {
+	principal, ok := auth.FromContext(c)
+	if !ok {
+		return ""
+	}
+	return principal.Name
+}
+
+// authorizeJobAccess reports whether c's principal may access job: jobs
+// created with no owner (auth disabled) are open to anyone, otherwise
+// the requester must be the job's own creator. Callers that get false
+// should respond 404 rather than 403, so a tenant probing for other
+// tenants' job IDs can't distinguish "not yours" from "doesn't exist".
+func authorizeJobAccess(c *gin.Context, job *jobs.Job) bool {
+	return job.Owner == "" || job.Owner == jobOwner(c)
+}
+
+// jobStatusHandler implements GET /api/jobs/:id.
+func jobStatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := jobStore.Get(c.Request.Context(), c.Param("id"))
+		if err != nil || !authorizeJobAccess(c, job) {
+			abortWithError(c, http.StatusNotFound, "job not found")
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// jobLogsHandler implements GET /api/jobs/:id/logs, returning the
+// per-token results gathered so far. When the caller asks for
+// text/event-stream, results are streamed as they are appended instead
+// of being returned as one JSON array, which matters for long-running
+// jobs a client wants to watch live.
+func jobLogsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		job, err := jobStore.Get(c.Request.Context(), id)
+		if err != nil || !authorizeJobAccess(c, job) {
+			abortWithError(c, http.StatusNotFound, "job not found")
+			return
+		}
+
+		if c.GetHeader("Accept") != "text/event-stream" {
+			c.JSON(http.StatusOK, job.Results)
+			return
+		}
+
+		streamJobLogs(c, id)
+	}
+}
+
+// streamJobLogs polls jobStore and writes newly-seen results as
+// server-sent events until the job reaches a terminal state or the
+// client disconnects.
+func streamJobLogs(c *gin.Context, id string) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	sent := 0
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+			job, err := jobStore.Get(c.Request.Context(), id)
+			if err != nil {
+				return
+			}
+			for _, result := range job.Results[sent:] {
+				c.SSEvent("result", result)
+			}
+			sent = len(job.Results)
+			c.Writer.Flush()
+
+			if job.Done() {
+				c.SSEvent("done", job)
+				c.Writer.Flush()
+				return
+			}
+		}
+	}
+}
+
+// jobCancelHandler implements DELETE /api/jobs/:id: it marks the job
+// cancelled and cancels the context its workers were given, so queued
+// notify.SendNotification calls observe ctx.Err() and stop instead of
+// continuing in the background.
+func jobCancelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		job, err := jobStore.Get(c.Request.Context(), id)
+		if err != nil || !authorizeJobAccess(c, job) {
+			abortWithError(c, http.StatusNotFound, "job not found")
+			return
+		}
+
+		if err := jobStore.Cancel(c.Request.Context(), id); err != nil {
+			abortWithError(c, http.StatusNotFound, "job not found")
+			return
+		}
+		jobCancels.Cancel(id)
+
+		c.JSON(http.StatusOK, gin.H{"job_id": id, "state": jobs.StateCancelled})
+	}
+}
+
+// handleNotificationAsync mirrors handleNotification's queueing logic
+// but, instead of collecting logs for a single synchronous response, it
+// records each token's outcome onto the job as it completes and leaves
+// the final state/FinishedAt set once every notification has been
+// attempted (or the context is cancelled first).
+func handleNotificationAsync(
+	ctx context.Context,
+	cfg *config.ConfYaml,
+	req notify.RequestPush,
+	q *queue.Queue,
+	jobID string,
+) {
+	_ = jobStore.Update(ctx, jobID, func(job *jobs.Job) {
+		job.State = jobs.StateRunning
+	})
+
+	var wg sync.WaitGroup
+	for i := range req.Notifications {
+		notification := &req.Notifications[i]
+		wg.Add(1)
+
+		taskErr := q.QueueTask(func(taskCtx context.Context) error {
+			defer wg.Done()
+
+			if ctx.Err() != nil {
+				recordTokenResults(ctx, cfg, jobID, notification, ctx.Err())
+				return ctx.Err()
+			}
+
+			_, err := notify.SendNotification(notification, cfg)
+			recordTokenResults(ctx, cfg, jobID, notification, err)
+			return err
+		})
+		if taskErr != nil {
+			recordTokenResults(ctx, cfg, jobID, notification, taskErr)
+			wg.Done()
+		}
+	}
+
+	wg.Wait()
+	finalizeJob(ctx, jobID)
+}
+
+// recordTokenResults appends one jobs.TokenResult per token in
+// notification, all sharing the outcome of the notify.SendNotification
+// call that covered them.
+func recordTokenResults(ctx context.Context, cfg *config.ConfYaml, jobID string, notification *notify.PushNotification, sendErr error) {
+	dispatchDeliveryReceipts(jobID, notification, cfg, sendErr)
+
+	_ = jobStore.Update(ctx, jobID, func(job *jobs.Job) {
+		for _, token := range notification.Tokens {
+			result := jobs.TokenResult{
+				Token:    token,
+				Platform: platformName(notification.Platform),
+				Success:  sendErr == nil,
+			}
+			if sendErr != nil {
+				result.Error = sendErr.Error()
+				job.Counts.Failure++
+			} else {
+				job.Counts.Success++
+			}
+			job.Results = append(job.Results, result)
+		}
+	})
+}
+
+// finalizeJob sets the job's terminal state from its accumulated
+// counts and reports its total duration.
+func finalizeJob(ctx context.Context, jobID string) {
+	defer jobCancels.Forget(jobID)
+
+	var createdAt time.Time
+	_ = jobStore.Update(ctx, jobID, func(job *jobs.Job) {
+		if job.State == jobs.StateCancelled {
+			job.FinishedAt = time.Now()
+			createdAt = job.CreatedAt
+			return
+		}
+		switch {
+		case job.Counts.Failure == 0:
+			job.State = jobs.StateSucceeded
+		case job.Counts.Success == 0:
+			job.State = jobs.StateFailed
+		default:
+			job.State = jobs.StatePartial
+		}
+		job.FinishedAt = time.Now()
+		createdAt = job.CreatedAt
+	})
+
+	if !createdAt.IsZero() {
+		jobs.ObserveFinish(createdAt)
+	}
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolki-app/gorush/auth"
+	"github.com/tolki-app/gorush/jobs"
+)
+
+func jobRequestContext(t *testing.T, id string, principal *auth.Principal) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/jobs/"+id, nil)
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	if principal != nil {
+		auth.SetPrincipal(c, principal)
+	}
+	return c, w
+}
+
+func TestAuthorizeJobAccess(t *testing.T) {
+	owned := &jobs.Job{Owner: "tenant-a"}
+	unowned := &jobs.Job{}
+
+	cases := []struct {
+		name      string
+		job       *jobs.Job
+		principal *auth.Principal
+		want      bool
+	}{
+		{"owner may access their own job", owned, &auth.Principal{Name: "tenant-a"}, true},
+		{"a different tenant may not access it", owned, &auth.Principal{Name: "tenant-b"}, false},
+		{"no principal (auth disabled) may not access an owned job", owned, nil, false},
+		{"an unowned job (created with auth disabled) is open to anyone", unowned, &auth.Principal{Name: "tenant-b"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := jobRequestContext(t, "job-1", tc.principal)
+			if got := authorizeJobAccess(c, tc.job); got != tc.want {
+				t.Errorf("authorizeJobAccess() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestJobStatusHandlerHidesOtherTenantsJobs drives jobStatusHandler
+// end-to-end against a real jobStore, confirming a tenant probing
+// another tenant's job id gets the same 404 as a nonexistent id rather
+// than a 403 that would confirm the job exists.
+func TestJobStatusHandlerHidesOtherTenantsJobs(t *testing.T) {
+	prev := jobStore
+	defer func() { jobStore = prev }()
+	jobStore = jobs.NewMemoryStore(10)
+
+	job, err := jobStore.Create(context.Background(), "tenant-a", 1, 0)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	c, w := jobRequestContext(t, job.ID, &auth.Principal{Name: "tenant-b"})
+	jobStatusHandler()(c)
+	if w.Code != 404 {
+		t.Fatalf("expected a non-owner to get 404, got %d", w.Code)
+	}
+
+	c, w = jobRequestContext(t, job.ID, &auth.Principal{Name: "tenant-a"})
+	jobStatusHandler()(c)
+	if w.Code != 200 {
+		t.Fatalf("expected the owner to get 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolki-app/gorush/auth"
+	"github.com/tolki-app/gorush/config"
+	"github.com/tolki-app/gorush/core"
+	"github.com/tolki-app/gorush/notify"
+)
+
+func bindPushRequestWithBody(t *testing.T, cfg *config.ConfYaml, principal *auth.Principal, body notify.RequestPush) (int, bool) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/push", bytes.NewReader(raw))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if principal != nil {
+		auth.SetPrincipal(c, principal)
+	}
+
+	_, ok := bindPushRequest(c, cfg)
+	return w.Code, ok
+}
+
+// TestBindPushRequestEnforcesPrincipalScope drives bindPushRequest with a
+// notify.RequestPush body mixing platforms against a principal scoped to
+// only one of them, confirming the 403 path in bindPushRequest actually
+// fires end-to-end rather than only being exercised indirectly through
+// Principal.AllowsPlatform/AllowsTopic unit tests.
+func TestBindPushRequestEnforcesPrincipalScope(t *testing.T) {
+	cfg := &config.ConfYaml{}
+	cfg.Core.MaxNotification = 10
+
+	scoped := &auth.Principal{Name: "tenant-a", Platforms: []string{"ios"}}
+
+	mixed := notify.RequestPush{
+		Notifications: []notify.PushNotification{
+			{Platform: core.PlatFormIos, Tokens: []string{"tok-ios"}, Message: "hi"},
+			{Platform: core.PlatFormAndroid, Tokens: []string{"tok-android"}, Message: "hi"},
+		},
+	}
+
+	code, ok := bindPushRequestWithBody(t, cfg, scoped, mixed)
+	if ok {
+		t.Fatal("expected bindPushRequest to reject a platform outside the principal's scope")
+	}
+	if code != 403 {
+		t.Fatalf("expected a 403 response, got %d", code)
+	}
+}
+
+func TestBindPushRequestAllowsInScopeNotifications(t *testing.T) {
+	cfg := &config.ConfYaml{}
+	cfg.Core.MaxNotification = 10
+
+	scoped := &auth.Principal{Name: "tenant-a", Platforms: []string{"ios", "android"}}
+
+	mixed := notify.RequestPush{
+		Notifications: []notify.PushNotification{
+			{Platform: core.PlatFormIos, Tokens: []string{"tok-ios"}, Message: "hi"},
+			{Platform: core.PlatFormAndroid, Tokens: []string{"tok-android"}, Message: "hi"},
+		},
+	}
+
+	_, ok := bindPushRequestWithBody(t, cfg, scoped, mixed)
+	if !ok {
+		t.Fatal("expected bindPushRequest to allow notifications within the principal's scope")
+	}
+}
+
+func TestBindPushRequestWithoutPrincipalSkipsScopeCheck(t *testing.T) {
+	cfg := &config.ConfYaml{}
+	cfg.Core.MaxNotification = 10
+
+	mixed := notify.RequestPush{
+		Notifications: []notify.PushNotification{
+			{Platform: core.PlatFormIos, Tokens: []string{"tok-ios"}, Message: "hi"},
+			{Platform: core.PlatFormHuawei, Tokens: []string{"tok-huawei"}, Message: "hi"},
+		},
+	}
+
+	_, ok := bindPushRequestWithBody(t, cfg, nil, mixed)
+	if !ok {
+		t.Fatal("expected an unauthenticated request (no principal) to bind without a scope check")
+	}
+}
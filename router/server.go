@@ -5,21 +5,25 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/tolki-app/gorush/auth"
 	"github.com/tolki-app/gorush/config"
 	"github.com/tolki-app/gorush/core"
 	"github.com/tolki-app/gorush/logx"
 	"github.com/tolki-app/gorush/metric"
 	"github.com/tolki-app/gorush/notify"
 	"github.com/tolki-app/gorush/status"
+	"github.com/tolki-app/gorush/tokenlc"
+	"github.com/tolki-app/gorush/webhooks"
 
 	api "github.com/appleboy/gin-status-api"
 	"github.com/gin-contrib/logger"
+	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/golang-queue/queue"
@@ -34,6 +38,22 @@ import (
 
 var doOnce sync.Once
 
+// cleanerRouter fans out terminal token-lifecycle events to the sinks
+// configured under cfg.Cleaner. It is built once, from the first
+// ConfYaml passed to routerEngine, since sinks may hold long-lived
+// connections (HTTP client, Redis client, ...).
+var cleanerRouter *tokenlc.Router
+
+// authManager resolves bearer tokens and API keys for the push API, or
+// is nil when cfg.API.Auth.Enabled is false.
+var authManager *auth.Manager
+
+// webhookDispatcher delivers per-token delivery receipts to subscriber
+// URLs (cfg.Webhooks.Default or a notification's own callback_url). It
+// is nil when the dispatcher fails to build, in which case delivery
+// receipts are silently skipped rather than blocking push delivery.
+var webhookDispatcher *webhooks.Dispatcher
+
 func abortWithError(c *gin.Context, code int, message string) {
 	c.AbortWithStatusJSON(code, gin.H{
 		"code":    code,
@@ -58,29 +78,57 @@ func versionHandler(c *gin.Context) {
 	})
 }
 
-func pushHandler(cfg *config.ConfYaml, q *queue.Queue) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var form notify.RequestPush
-		var msg string
+// bindPushRequest binds and validates a notify.RequestPush body, shared
+// by the synchronous and async push handlers. It writes the error
+// response itself and returns ok=false when validation fails.
+func bindPushRequest(c *gin.Context, cfg *config.ConfYaml) (notify.RequestPush, bool) {
+	var form notify.RequestPush
+	var msg string
+
+	if err := c.ShouldBindWith(&form, binding.JSON); err != nil {
+		msg = "Missing notifications field."
+		logx.LogAccess.Debug(err)
+		abortWithError(c, http.StatusBadRequest, msg)
+		return form, false
+	}
 
-		if err := c.ShouldBindWith(&form, binding.JSON); err != nil {
-			msg = "Missing notifications field."
-			logx.LogAccess.Debug(err)
-			abortWithError(c, http.StatusBadRequest, msg)
-			return
+	if len(form.Notifications) == 0 {
+		msg = "Notifications field is empty."
+		logx.LogAccess.Debug(msg)
+		abortWithError(c, http.StatusBadRequest, msg)
+		return form, false
+	}
+
+	if int64(len(form.Notifications)) > cfg.Core.MaxNotification {
+		msg = fmt.Sprintf("Number of notifications(%d) over limit(%d)", len(form.Notifications), cfg.Core.MaxNotification)
+		logx.LogAccess.Debug(msg)
+		abortWithError(c, http.StatusBadRequest, msg)
+		return form, false
+	}
+
+	if principal, ok := auth.FromContext(c); ok {
+		for _, notification := range form.Notifications {
+			if !principal.AllowsPlatform(platformName(notification.Platform)) || !principal.AllowsTopic(notification.To) {
+				msg = fmt.Sprintf("principal %q is not scoped to push this notification", principal.Name)
+				logx.LogAccess.Debug(msg)
+				abortWithError(c, http.StatusForbidden, msg)
+				return form, false
+			}
 		}
+	}
+
+	return form, true
+}
 
-		if len(form.Notifications) == 0 {
-			msg = "Notifications field is empty."
-			logx.LogAccess.Debug(msg)
-			abortWithError(c, http.StatusBadRequest, msg)
+func pushHandler(cfg *config.ConfYaml, q *queue.Queue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query("mode") == "async" {
+			asyncPushHandler(cfg, q)(c)
 			return
 		}
 
-		if int64(len(form.Notifications)) > cfg.Core.MaxNotification {
-			msg = fmt.Sprintf("Number of notifications(%d) over limit(%d)", len(form.Notifications), cfg.Core.MaxNotification)
-			logx.LogAccess.Debug(msg)
-			abortWithError(c, http.StatusBadRequest, msg)
+		form, ok := bindPushRequest(c, cfg)
+		if !ok {
 			return
 		}
 
@@ -145,6 +193,19 @@ func sysStatsHandler() gin.HandlerFunc {
 	}
 }
 
+// cleanerStatsHandler reports the in-process tally of tokens routed
+// through the token-lifecycle sinks, so operators can see which reasons
+// flow through without scraping /metrics.
+func cleanerStatsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cleanerRouter == nil {
+			c.JSON(http.StatusOK, gin.H{"entries": []tokenlc.Entry{}})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"entries": cleanerRouter.Stats().Snapshot()})
+	}
+}
+
 // StatMiddleware response time, status code count, etc.
 func StatMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -163,12 +224,44 @@ func autoTLSServer(cfg *config.ConfYaml, q *queue.Queue) *http.Server {
 
 	//nolint:gosec
 	return &http.Server{
-		Addr:      ":https",
-		TLSConfig: &tls.Config{GetCertificate: m.GetCertificate},
-		Handler:   routerEngine(cfg, q),
+		Addr:              ":https",
+		TLSConfig:         &tls.Config{GetCertificate: m.GetCertificate},
+		Handler:           routerEngine(cfg, q),
+		ReadHeaderTimeout: cfg.Core.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Core.ReadTimeout,
+		WriteTimeout:      cfg.Core.WriteTimeout,
+		IdleTimeout:       cfg.Core.IdleTimeout,
 	}
 }
 
+// mtlsServer runs a parallel listener on cfg.API.MTLS.Addr that requires
+// and verifies a client certificate signed by cfg.API.MTLS.ClientCAFile,
+// for deployments that want network-level authentication in addition to
+// (or instead of) bearer tokens and API keys.
+func mtlsServer(cfg *config.ConfYaml, q *queue.Queue) (*http.Server, error) {
+	tlsConfig, err := cfg.API.MTLS.TLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("mtls: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.API.MTLS.CertFile, cfg.API.MTLS.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("mtls: loading server certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+
+	//nolint:gosec
+	return &http.Server{
+		Addr:              cfg.API.MTLS.Addr,
+		TLSConfig:         tlsConfig,
+		Handler:           routerEngine(cfg, q),
+		ReadHeaderTimeout: cfg.Core.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Core.ReadTimeout,
+		WriteTimeout:      cfg.Core.WriteTimeout,
+		IdleTimeout:       cfg.Core.IdleTimeout,
+	}, nil
+}
+
 func routerEngine(cfg *config.ConfYaml, q *queue.Queue) *gin.Engine {
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	if cfg.Core.Mode == "debug" {
@@ -191,6 +284,34 @@ func routerEngine(cfg *config.ConfYaml, q *queue.Queue) *gin.Engine {
 	doOnce.Do(func() {
 		m := metric.NewMetrics(q)
 		prometheus.MustRegister(m)
+
+		router, err := tokenlc.NewRouterFromConfig(cfg.Cleaner)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to build token-lifecycle router, falling back to no-op")
+			router = tokenlc.NewRouter(nil, nil)
+		}
+		cleanerRouter = router
+
+		wd, err := webhooks.NewDispatcherFromConfig(cfg.Webhooks)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to build webhook dispatcher, delivery receipts disabled")
+		} else {
+			webhookDispatcher = wd
+			cleanerRouter.AddSink(webhooks.NewInvalidatedSink(webhookDispatcher, cfg.Webhooks.Default.Spec()))
+		}
+
+		jobStore = newJobStore(cfg)
+
+		if cfg.API.Auth.Enabled {
+			mgr, err := auth.NewManager(cfg.API.Auth)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to load auth token store, push API will reject all requests")
+			}
+			authManager = mgr
+			if authManager != nil {
+				authManager.WatchSIGHUP()
+			}
+		}
 	})
 
 	// set server mode
@@ -199,6 +320,52 @@ func routerEngine(cfg *config.ConfYaml, q *queue.Queue) *gin.Engine {
 	r := gin.New()
 
 	// Global middleware
+	r.Use(gin.Recovery())
+	r.Use(VersionMiddleware())
+	r.Use(StatMiddleware())
+	r.Use(auth.MTLSPrincipalMiddleware(cfg.API.MTLS))
+
+	// When auth is enabled but the token store failed to load, an empty
+	// Store stands in, so the push and job routes reject every credential.
+	var authStore *auth.Store
+	switch {
+	case authManager != nil:
+		authStore = authManager.Store()
+	case cfg.API.Auth.Enabled:
+		authStore = auth.NewStore()
+	}
+
+	pushHandlers := make([]gin.HandlerFunc, 0, 2)
+	if authStore != nil {
+		pushHandlers = append(pushHandlers, auth.Middleware(authStore))
+		r.GET("/api/auth/whoami", auth.Middleware(authStore), auth.WhoAmIHandler)
+	}
+	pushHandlers = append(pushHandlers, pushHandler(cfg, q))
+	r.POST(cfg.API.PushURI, TimeoutMiddleware(cfg.API.Timeouts.Push, pushHandlers...))
+
+	jobsRoute := r.Group(cfg.API.JobURI)
+	if authStore != nil {
+		jobsRoute.Use(auth.Middleware(authStore))
+	}
+	jobsRoute.GET("/:id", jobStatusHandler())
+	jobsRoute.GET("/:id/logs", jobLogsHandler())
+	jobsRoute.DELETE("/:id", jobCancelHandler())
+
+	r.GET("/version", versionHandler)
+	r.GET("/", rootHandler)
+
+	return r
+}
+
+// adminEngine mounts the operational endpoints that must keep working
+// even when the public listener is saturated by slow push/APNs/FCM
+// calls: health, metrics, pprof, and the stat/config introspection
+// routes. It is served on its own listener (see adminServer) so that a
+// Kubernetes liveness probe hitting cfg.API.HealthURI never queues
+// behind a push request.
+func adminEngine(cfg *config.ConfYaml, q *queue.Queue) *gin.Engine {
+	r := gin.New()
+
 	r.Use(logger.SetLogger(
 		logger.WithUTC(true),
 		logger.WithSkipPath([]string{
@@ -207,23 +374,53 @@ func routerEngine(cfg *config.ConfYaml, q *queue.Queue) *gin.Engine {
 		}),
 	))
 	r.Use(gin.Recovery())
-	r.Use(VersionMiddleware())
-	r.Use(StatMiddleware())
 
 	r.GET(cfg.API.StatGoURI, api.GinHandler)
 	r.GET(cfg.API.StatAppURI, appStatusHandler(q))
 	r.GET(cfg.API.ConfigURI, configHandler(cfg))
 	r.GET(cfg.API.SysStatURI, sysStatsHandler())
-	r.POST(cfg.API.PushURI, pushHandler(cfg, q))
 	r.GET(cfg.API.MetricURI, metricsHandler)
+	r.GET("/api/cleaner/stats", cleanerStatsHandler())
 	r.GET(cfg.API.HealthURI, heartbeatHandler)
 	r.HEAD(cfg.API.HealthURI, heartbeatHandler)
-	r.GET("/version", versionHandler)
-	r.GET("/", rootHandler)
+	pprof.Register(r)
 
 	return r
 }
 
+// adminServer binds cfg.Core.AdminAddress (default "127.0.0.1:0", i.e. a
+// random free port) and returns an *http.Server serving adminEngine on
+// it. The bound address is logged so operators who requested port 0 can
+// discover which port to point their probes at.
+func adminServer(cfg *config.ConfYaml, q *queue.Queue) (*http.Server, error) {
+	addr := cfg.Core.AdminAddress
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("admin listener: %w", err)
+	}
+	log.Info().Str("addr", listener.Addr().String()).Msg("admin listener bound")
+
+	srv := &http.Server{
+		Handler:           adminEngine(cfg, q),
+		ReadHeaderTimeout: cfg.Core.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Core.ReadTimeout,
+		WriteTimeout:      cfg.Core.WriteTimeout,
+		IdleTimeout:       cfg.Core.IdleTimeout,
+	}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("admin listener stopped")
+		}
+	}()
+
+	return srv, nil
+}
+
 // markFailedNotification adds failure logs for all tokens in push notification
 func markFailedNotification(
 	cfg *config.ConfYaml,
@@ -250,7 +447,7 @@ func markFailedNotification(
 
 // HandleNotification add notification to queue list.
 func handleNotification(
-	_ context.Context,
+	ctx context.Context,
 	cfg *config.ConfYaml,
 	req notify.RequestPush,
 	q *queue.Queue,
@@ -290,32 +487,31 @@ func handleNotification(
 
 		if core.IsLocalQueue(core.Queue(cfg.Queue.Engine)) && cfg.Core.Sync {
 			func(msg *notify.PushNotification, cfg *config.ConfYaml) {
-				if err := q.QueueTask(func(ctx context.Context) error {
+				if err := q.QueueTask(func(taskCtx context.Context) error {
 					defer wg.Done()
+
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+
 					resp, err := notify.SendNotification(msg, cfg)
-					// Legacy code
-					// if err != nil {
-					// 	return err
-					// }
-					// Warning! Debug only. Delete or comment on deploy!!!
-					// for _, token := range msg.Tokens {
-					// 	go deleteUnregisteredToken(token, "http://127.0.0.1:6060")
-					// }
+					dispatchDeliveryReceipts("", msg, cfg, err)
 					if err != nil {
-						if strings.Contains(err.Error(), "Unregistered") {
-							logx.LogError.Errorf("Unregistered token: %v", msg.Tokens)
-							for _, token := range msg.Tokens {
-								// TODO: Enivironment CLEANER_API_URL
-								// in section api config???
-								cleanerApiUrl := os.Getenv("CLEANER_API_URL")
-								if cleanerApiUrl == "" {
-									cleanerApiUrl = "https://cleaner.tolki.app" // Default value if not set
-								}
-								go deleteUnregisteredToken(token, cleanerApiUrl)
-							}
-						} else {
+						reason := notify.ClassifyError(msg.Platform, err)
+						if reason == tokenlc.ReasonUnknown {
 							return err
 						}
+						logx.LogError.Errorf("terminal token error (%s): %v", reason, msg.Tokens)
+						for _, token := range msg.Tokens {
+							cleanerRouter.Dispatch(taskCtx, tokenlc.InvalidationEvent{
+								Token:           token,
+								Platform:        platformName(msg.Platform),
+								Topic:           msg.To,
+								Reason:          reason,
+								RawProviderCode: err.Error(),
+								OccurredAt:      time.Now(),
+							})
+						}
 					}
 					// add log
 					logs = append(logs, resp.Logs...)
@@ -348,26 +544,50 @@ func handleNotification(
 	return count, logs
 }
 
-// deleteUnregisteredToken delete unregistered token with tolki-app cleaner service in k8s
-func deleteUnregisteredToken(token string, cleanerServiceURL string) {
-	client := &http.Client{}
-	req, err := http.NewRequest("DELETE", cleanerServiceURL+"/"+token, nil)
-	if err != nil {
-		logx.LogError.Errorf("Error creating request: %v", err)
+// dispatchDeliveryReceipts enqueues one webhooks.DeliveryReceipt per
+// token in notification, reporting sendErr's outcome. It resolves the
+// destination from the notification's own callback_url/callback_events
+// if set, falling back to cfg.Webhooks.Default; jobID is empty for the
+// synchronous push path, which has no job to reference.
+func dispatchDeliveryReceipts(jobID string, notification *notify.PushNotification, cfg *config.ConfYaml, sendErr error) {
+	if webhookDispatcher == nil {
 		return
 	}
 
-	// Implementing a simple retry mechanism for token deletion
-	for attempts := 0; attempts < 3; attempts++ {
-		resp, err := client.Do(req)
-		if err != nil {
-			logx.LogError.Errorf("Error sending DELETE request: %v", err)
-			time.Sleep(2 * time.Second)
-			continue
-		}
-		if resp.StatusCode == http.StatusOK {
-			break
-		}
-		time.Sleep(2 * time.Second) // Wait before retrying
+	status := webhooks.EventDelivered
+	var reason, providerCode string
+	if sendErr != nil {
+		status = webhooks.EventFailed
+		providerCode = sendErr.Error()
+		reason = string(notify.ClassifyError(notification.Platform, sendErr))
+	}
+
+	spec := cfg.Webhooks.ResolveSpec(notification.CallbackURL, notification.CallbackSecret, notification.CallbackEvents)
+	for _, token := range notification.Tokens {
+		webhookDispatcher.Enqueue(webhooks.DeliveryReceipt{
+			JobID:        jobID,
+			Token:        token,
+			Platform:     platformName(notification.Platform),
+			Status:       status,
+			ProviderCode: providerCode,
+			Reason:       reason,
+			Timestamp:    time.Now(),
+		}, spec)
+	}
+}
+
+// platformName converts a core.PlatFormXxx constant into the string used
+// by tokenlc.InvalidationEvent, which is transport-agnostic and should
+// not depend on the core package's integer encoding.
+func platformName(platform int) string {
+	switch platform {
+	case core.PlatFormIos:
+		return "ios"
+	case core.PlatFormAndroid:
+		return "android"
+	case core.PlatFormHuawei:
+		return "huawei"
+	default:
+		return "unknown"
 	}
 }
@@ -0,0 +1,135 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeoutWriter wraps gin.ResponseWriter so that once a request's
+// deadline has fired, any write the original handler goroutine is still
+// attempting is silently discarded instead of racing with (or following)
+// the timeout response that has already gone out on the wire.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	mu       sync.Mutex
+	timedOut bool
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// writeTimeout sends the timeout response directly through the
+// underlying ResponseWriter, then flags the writer so the original
+// handler's own writes (it may still be running) are discarded instead
+// of corrupting the response that was just sent.
+func (w *timeoutWriter) writeTimeout(code int, body []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	w.timedOut = true
+	w.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.ResponseWriter.WriteHeader(code)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// TimeoutMiddleware bounds a request to d, matching http.TimeoutHandler
+// semantics: handlers run to completion on their own goroutine, but if d
+// elapses first, the client gets a timeout response immediately and the
+// request's context is cancelled so in-flight work (notably
+// notify.SendNotification calls queued from pushHandler) can observe
+// ctx.Err() and stop instead of continuing in the background.
+//
+// TimeoutMiddleware must be registered as the sole handler for a route,
+// with the route's real handlers passed in as handlers, rather than
+// chained afterwards with further r.Use()/r.POST() calls. Once the
+// timeout fires, the handlers keep running against a *copy* of the gin
+// context (c.Copy()) on their own goroutine; calling any *gin.Context
+// method that mutates shared state (Next, Abort, the index gin.Context
+// tracks internally) from both that goroutine and the one that gave up
+// waiting would race, so the waiting goroutine never touches c again
+// past the timeout — it only writes through tw, which is its own mutex.
+//
+// d <= 0 disables the timeout and runs handlers in place on c.
+func TimeoutMiddleware(d time.Duration, handlers ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d <= 0 {
+			runHandlers(c, handlers)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		tw := &timeoutWriter{ResponseWriter: c.Writer}
+
+		cp := c.Copy()
+		cp.Request = cp.Request.WithContext(ctx)
+		cp.Writer = tw
+
+		finished := make(chan struct{})
+		go func() {
+			defer close(finished)
+			runHandlers(cp, handlers)
+		}()
+
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			body, _ := json.Marshal(gin.H{
+				"code":    http.StatusGatewayTimeout,
+				"message": "request timed out",
+			})
+			tw.writeTimeout(http.StatusGatewayTimeout, body)
+		}
+	}
+}
+
+// runHandlers invokes handlers in order against c, stopping early once one
+// of them has written a response (e.g. auth.Middleware rejecting the
+// request with a 401). It is used in place of gin's own Next()/handler-chain
+// traversal so TimeoutMiddleware can run handlers against either the live
+// context or a c.Copy(), uniformly.
+//
+// It deliberately checks c.Writer.Written() rather than c.IsAborted():
+// gin.Context.Copy() sets the copy's internal abort index to its
+// "aborted" value by construction (a copy is meant to outlive the
+// handler chain, not keep iterating it), so on a c.Copy()-derived
+// context IsAborted() is already true before any handler here has run,
+// regardless of whether one of them actually aborts. Written() has no
+// such poisoning -- it only flips once a handler has genuinely sent a
+// response -- so it is the one signal that behaves the same on the live
+// context and on a copy.
+func runHandlers(c *gin.Context, handlers []gin.HandlerFunc) {
+	for _, h := range handlers {
+		h(c)
+		if c.Writer.Written() {
+			return
+		}
+	}
+}
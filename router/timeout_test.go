@@ -0,0 +1,104 @@
+package router
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTimeoutTestContext(t *testing.T) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/push", nil)
+	return c, w
+}
+
+// TestTimeoutMiddlewareRunsEveryHandlerOnSuccess is a regression test for
+// a bug where TimeoutMiddleware ran handlers against a c.Copy(), whose
+// IsAborted() is true by construction (Copy() poisons it for exactly
+// this reason) even though nothing aborted -- so runHandlers mistook a
+// successful first handler for an abort and never ran the second one.
+func TestTimeoutMiddlewareRunsEveryHandlerOnSuccess(t *testing.T) {
+	var first, second bool
+	mw := TimeoutMiddleware(time.Second,
+		func(c *gin.Context) { first = true },
+		func(c *gin.Context) { second = true; c.Status(200) },
+	)
+
+	c, w := newTimeoutTestContext(t)
+	mw(c)
+
+	if !first || !second {
+		t.Fatalf("expected both handlers to run, got first=%v second=%v", first, second)
+	}
+	if w.Code != 200 {
+		t.Fatalf("expected a 200 response, got %d", w.Code)
+	}
+}
+
+// TestTimeoutMiddlewareStopsAfterAHandlerWrites confirms runHandlers
+// still stops the chain once a handler actually writes a response (the
+// real "aborted" signal), instead of running subsequent handlers.
+func TestTimeoutMiddlewareStopsAfterAHandlerWrites(t *testing.T) {
+	var second bool
+	mw := TimeoutMiddleware(time.Second,
+		func(c *gin.Context) { c.AbortWithStatus(401) },
+		func(c *gin.Context) { second = true },
+	)
+
+	c, w := newTimeoutTestContext(t)
+	mw(c)
+
+	if second {
+		t.Fatal("expected the second handler to be skipped once the first wrote a response")
+	}
+	if w.Code != 401 {
+		t.Fatalf("expected the first handler's 401 response, got %d", w.Code)
+	}
+}
+
+// TestTimeoutMiddlewareWritesTimeoutResponse confirms a handler that
+// outlives d gets cut off with a 504 instead of the client hanging.
+func TestTimeoutMiddlewareWritesTimeoutResponse(t *testing.T) {
+	mw := TimeoutMiddleware(10*time.Millisecond, func(c *gin.Context) {
+		time.Sleep(100 * time.Millisecond)
+		c.Status(200)
+	})
+
+	c, w := newTimeoutTestContext(t)
+	mw(c)
+
+	if w.Code != 504 {
+		t.Fatalf("expected a 504 timeout response, got %d", w.Code)
+	}
+}
+
+// TestTimeoutMiddlewareDisabledRunsInPlace confirms d <= 0 runs handlers
+// directly against c rather than a copy.
+func TestTimeoutMiddlewareDisabledRunsInPlace(t *testing.T) {
+	var ran bool
+	var got *gin.Context
+	mw := TimeoutMiddleware(0, func(c *gin.Context) {
+		ran = true
+		got = c
+		c.Status(200)
+	})
+
+	c, w := newTimeoutTestContext(t)
+	mw(c)
+
+	if !ran {
+		t.Fatal("expected the handler to run")
+	}
+	if got != c {
+		t.Fatal("expected the handler to run against c itself, not a copy")
+	}
+	if w.Code != 200 {
+		t.Fatalf("expected a 200 response, got %d", w.Code)
+	}
+}
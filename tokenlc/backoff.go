@@ -0,0 +1,23 @@
+package tokenlc
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff returns an exponentially increasing delay for attempt (0-based),
+// capped at max and jittered by up to +/-25% so that a burst of failures
+// doesn't retry in lockstep.
+func backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(d)/2+1)) - d/4
+	d += jitter
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
@@ -0,0 +1,75 @@
+package tokenlc
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CleanerConfig is embedded as ConfYaml.Cleaner. Each entry in Engines
+// selects one sink; "http", "webhook", "redis" and "noop" are
+// recognized, and the corresponding <Engine>Config block supplies its
+// settings. "stream" is not recognized here: a StreamSink is only built
+// by calling NewStreamSink with a StreamPublisher. Terminal optionally
+// replaces the set of reasons dispatched to sinks; when empty,
+// TerminalReasons is used.
+type CleanerConfig struct {
+	Engines  []string       `yaml:"engines" json:"engines"`
+	Terminal []string       `yaml:"terminal" json:"terminal"`
+	HTTP     HTTPSinkConfig `yaml:"http" json:"http"`
+	Webhook  struct {
+		URL       string `yaml:"url" json:"url"`
+		QueueSize int    `yaml:"queue_size" json:"queue_size"`
+	} `yaml:"webhook" json:"webhook"`
+	Redis struct {
+		Addr      string `yaml:"addr" json:"addr"`
+		Key       string `yaml:"key" json:"key"`
+		UseStream bool   `yaml:"use_stream" json:"use_stream"`
+		QueueSize int    `yaml:"queue_size" json:"queue_size"`
+	} `yaml:"redis" json:"redis"`
+}
+
+// terminalReasons converts CleanerConfig.Terminal into the map shape
+// Router expects, falling back to TerminalReasons when unset.
+func (c CleanerConfig) terminalReasons() map[Reason]bool {
+	if len(c.Terminal) == 0 {
+		return TerminalReasons
+	}
+	out := make(map[Reason]bool, len(c.Terminal))
+	for _, r := range c.Terminal {
+		out[Reason(r)] = true
+	}
+	return out
+}
+
+// NewRouterFromConfig builds a Router from CleanerConfig, instantiating
+// one sink per configured engine. An unrecognized engine name is an
+// error rather than being silently skipped, since a typo'd engine name
+// would otherwise mean tokens are never cleaned up at all.
+func NewRouterFromConfig(cfg CleanerConfig) (*Router, error) {
+	sinks := make([]TokenLifecycleSink, 0, len(cfg.Engines))
+	for _, engine := range cfg.Engines {
+		switch engine {
+		case "http":
+			sinks = append(sinks, NewHTTPSink(cfg.HTTP))
+		case "webhook":
+			sinks = append(sinks, NewWebhookSink(WebhookSinkConfig{
+				URL:       cfg.Webhook.URL,
+				QueueSize: cfg.Webhook.QueueSize,
+			}))
+		case "redis":
+			sinks = append(sinks, NewRedisSink(RedisSinkConfig{
+				Client:    redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr}),
+				Key:       cfg.Redis.Key,
+				UseStream: cfg.Redis.UseStream,
+				QueueSize: cfg.Redis.QueueSize,
+			}))
+		case "noop":
+			sinks = append(sinks, NoopSink{})
+		default:
+			return nil, fmt.Errorf("tokenlc: unknown cleaner engine %q (stream sinks must be constructed directly, since they require a live publisher)", engine)
+		}
+	}
+
+	return NewRouter(sinks, cfg.terminalReasons()), nil
+}
@@ -0,0 +1,23 @@
+package tokenlc
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// tokensInvalidated counts every Invalidate call a sink completes,
+// labelled by reason, sink name, and whether it succeeded. Operators can
+// use it to see which reasons flow through which sink without grepping
+// logs.
+var tokensInvalidated = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gorush_tokens_invalidated_total",
+		Help: "Total number of tokens forwarded to a lifecycle sink for invalidation.",
+	},
+	[]string{"reason", "sink", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(tokensInvalidated)
+}
+
+func observeInvalidation(reason, sink, result string) {
+	tokensInvalidated.WithLabelValues(reason, sink, result).Inc()
+}
@@ -0,0 +1,84 @@
+package tokenlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultQueueSize  = 1000
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+	defaultMaxDelay   = 10 * time.Second
+)
+
+// deliverFunc performs the sink-specific work for a single event. It
+// should return a non-nil error only for failures worth retrying.
+type deliverFunc func(ctx context.Context, event InvalidationEvent) error
+
+// boundedQueue runs deliver against a single worker goroutine pulling
+// from a fixed-size channel, making at most defaultMaxRetries delivery
+// attempts per event with jittered exponential backoff after each
+// failure. Unlike the previous
+// per-token goroutine-plus-sleep loop, a full queue drops the newest
+// event instead of blocking the caller.
+type boundedQueue struct {
+	events  chan InvalidationEvent
+	deliver deliverFunc
+	done    chan struct{}
+}
+
+func newBoundedQueue(size int, deliver deliverFunc) *boundedQueue {
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	q := &boundedQueue{
+		events:  make(chan InvalidationEvent, size),
+		deliver: deliver,
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// enqueue adds event to the queue, dropping it if the queue is full.
+func (q *boundedQueue) enqueue(event InvalidationEvent) bool {
+	select {
+	case q.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *boundedQueue) run() {
+	defer close(q.done)
+	for event := range q.events {
+		q.deliverWithRetry(event)
+	}
+}
+
+func (q *boundedQueue) deliverWithRetry(event InvalidationEvent) {
+	var err error
+	for attempt := 0; attempt < defaultMaxRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultMaxDelay)
+		err = q.deliver(ctx, event)
+		cancel()
+		if err == nil {
+			return
+		}
+		time.Sleep(backoff(attempt, defaultBaseDelay, defaultMaxDelay))
+	}
+	log.Error().Err(err).
+		Str("token", event.Token).
+		Msg("tokenlc: giving up on invalidation event after retries")
+}
+
+// close closes the event channel and waits until the worker has handled
+// every event already queued. An enqueue after close panics.
+func (q *boundedQueue) close() {
+	close(q.events)
+	<-q.done
+}
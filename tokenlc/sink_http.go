@@ -0,0 +1,78 @@
+package tokenlc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSinkConfig configures HTTPSink.
+type HTTPSinkConfig struct {
+	// URL is the base cleaner endpoint; the token is appended as
+	// "<URL>/<token>", matching the historical gorush cleaner API.
+	URL string
+	// QueueSize bounds the number of pending deletions; 0 uses the
+	// package default.
+	QueueSize int
+	// Client is the http.Client used for requests; a zero value builds a
+	// client with a sane timeout.
+	Client *http.Client
+}
+
+// HTTPSink reproduces the original behavior of deleteUnregisteredToken:
+// it issues an HTTP DELETE to "<URL>/<token>" for every invalidation
+// event, now routed through a bounded queue with backoff instead of a
+// goroutine-per-token sleep loop.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+	queue  *boundedQueue
+}
+
+// NewHTTPSink builds an HTTPSink from cfg.
+func NewHTTPSink(cfg HTTPSinkConfig) *HTTPSink {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	s := &HTTPSink{url: cfg.URL, client: client}
+	s.queue = newBoundedQueue(cfg.QueueSize, s.deliver)
+	return s
+}
+
+// Name implements TokenLifecycleSink.
+func (s *HTTPSink) Name() string { return "http" }
+
+// Invalidate implements TokenLifecycleSink.
+func (s *HTTPSink) Invalidate(_ context.Context, event InvalidationEvent) error {
+	if !s.queue.enqueue(event) {
+		return fmt.Errorf("tokenlc: http sink queue full, dropping token %s", event.Token)
+	}
+	return nil
+}
+
+// Close implements TokenLifecycleSink.
+func (s *HTTPSink) Close() error {
+	s.queue.close()
+	return nil
+}
+
+func (s *HTTPSink) deliver(ctx context.Context, event InvalidationEvent) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.url+"/"+event.Token, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tokenlc: cleaner returned status %d for token %s", resp.StatusCode, event.Token)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package tokenlc
+
+import "context"
+
+// NoopSink discards every invalidation event. It exists so that
+// "cleaner.engines: [noop]" (or an empty engines list) is a valid,
+// explicit configuration rather than a special case in the router.
+type NoopSink struct{}
+
+// Name implements TokenLifecycleSink.
+func (NoopSink) Name() string { return "noop" }
+
+// Invalidate implements TokenLifecycleSink.
+func (NoopSink) Invalidate(context.Context, InvalidationEvent) error { return nil }
+
+// Close implements TokenLifecycleSink.
+func (NoopSink) Close() error { return nil }
@@ -0,0 +1,69 @@
+package tokenlc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisSinkConfig configures RedisSink.
+type RedisSinkConfig struct {
+	Client *redis.Client
+	// Key is the list (or stream, when UseStream is set) key that
+	// invalidation events are pushed onto.
+	Key       string
+	UseStream bool
+	QueueSize int
+}
+
+// RedisSink pushes invalidation events onto a Redis list (via RPUSH) or
+// stream (via XADD) so that a separate consumer process can perform the
+// actual cleanup. This is the preferred sink for operators who already
+// run a Redis-backed token store.
+type RedisSink struct {
+	client    *redis.Client
+	key       string
+	useStream bool
+	queue     *boundedQueue
+}
+
+// NewRedisSink builds a RedisSink from cfg.
+func NewRedisSink(cfg RedisSinkConfig) *RedisSink {
+	s := &RedisSink{client: cfg.Client, key: cfg.Key, useStream: cfg.UseStream}
+	s.queue = newBoundedQueue(cfg.QueueSize, s.deliver)
+	return s
+}
+
+// Name implements TokenLifecycleSink.
+func (s *RedisSink) Name() string { return "redis" }
+
+// Invalidate implements TokenLifecycleSink.
+func (s *RedisSink) Invalidate(_ context.Context, event InvalidationEvent) error {
+	if !s.queue.enqueue(event) {
+		return fmt.Errorf("tokenlc: redis sink queue full, dropping token %s", event.Token)
+	}
+	return nil
+}
+
+// Close implements TokenLifecycleSink.
+func (s *RedisSink) Close() error {
+	s.queue.close()
+	return nil
+}
+
+func (s *RedisSink) deliver(ctx context.Context, event InvalidationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if s.useStream {
+		return s.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.key,
+			Values: map[string]interface{}{"event": string(payload)},
+		}).Err()
+	}
+	return s.client.RPush(ctx, s.key, payload).Err()
+}
@@ -0,0 +1,64 @@
+package tokenlc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// StreamPublisher abstracts the single operation tokenlc needs from a
+// message broker client: publish a payload to a named subject/topic. Both
+// the NATS and Kafka client libraries are trivially adapted to this
+// shape, which keeps tokenlc from depending on either directly.
+type StreamPublisher interface {
+	Publish(ctx context.Context, subject string, payload []byte) error
+}
+
+// StreamSinkConfig configures StreamSink.
+type StreamSinkConfig struct {
+	Publisher StreamPublisher
+	// Subject is the NATS subject or Kafka topic events are published to.
+	Subject   string
+	QueueSize int
+}
+
+// StreamSink publishes invalidation events to a NATS subject or Kafka
+// topic via StreamPublisher, for operators whose cleanup pipeline is
+// already built around a message broker rather than Redis or HTTP.
+type StreamSink struct {
+	publisher StreamPublisher
+	subject   string
+	queue     *boundedQueue
+}
+
+// NewStreamSink builds a StreamSink from cfg.
+func NewStreamSink(cfg StreamSinkConfig) *StreamSink {
+	s := &StreamSink{publisher: cfg.Publisher, subject: cfg.Subject}
+	s.queue = newBoundedQueue(cfg.QueueSize, s.deliver)
+	return s
+}
+
+// Name implements TokenLifecycleSink.
+func (s *StreamSink) Name() string { return "stream" }
+
+// Invalidate implements TokenLifecycleSink.
+func (s *StreamSink) Invalidate(_ context.Context, event InvalidationEvent) error {
+	if !s.queue.enqueue(event) {
+		return fmt.Errorf("tokenlc: stream sink queue full, dropping token %s", event.Token)
+	}
+	return nil
+}
+
+// Close implements TokenLifecycleSink.
+func (s *StreamSink) Close() error {
+	s.queue.close()
+	return nil
+}
+
+func (s *StreamSink) deliver(ctx context.Context, event InvalidationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.publisher.Publish(ctx, s.subject, payload)
+}
@@ -0,0 +1,93 @@
+package tokenlc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookSinkConfig configures WebhookSink.
+type WebhookSinkConfig struct {
+	// URL receives a JSON POST batch of invalidation events.
+	URL string
+	// BatchSize and FlushInterval are not read by NewWebhookSink; every
+	// event is posted on its own (see NewWebhookSink).
+	BatchSize     int
+	FlushInterval time.Duration
+	QueueSize     int
+	Client        *http.Client
+}
+
+// webhookBatch is the JSON body posted to WebhookSinkConfig.URL.
+type webhookBatch struct {
+	Events []InvalidationEvent `json:"events"`
+}
+
+// WebhookSink POSTs invalidation events as JSON to a configured URL, one
+// event per request in a webhookBatch body, for operators who want to consume cleanup events in
+// their own service rather than exposing a DELETE endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	queue  *boundedQueue
+}
+
+// NewWebhookSink builds a WebhookSink from cfg. Batching is intentionally
+// simple: each Invalidate call enqueues one event and the queue worker
+// posts it individually, wrapped in a single-element batch, so that a
+// slow receiver only affects its own backoff rather than stalling a
+// shared batch buffer.
+func NewWebhookSink(cfg WebhookSinkConfig) *WebhookSink {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	s := &WebhookSink{url: cfg.URL, client: client}
+	s.queue = newBoundedQueue(cfg.QueueSize, s.deliver)
+	return s
+}
+
+// Name implements TokenLifecycleSink.
+func (s *WebhookSink) Name() string { return "webhook" }
+
+// Invalidate implements TokenLifecycleSink.
+func (s *WebhookSink) Invalidate(_ context.Context, event InvalidationEvent) error {
+	if !s.queue.enqueue(event) {
+		return fmt.Errorf("tokenlc: webhook sink queue full, dropping token %s", event.Token)
+	}
+	return nil
+}
+
+// Close implements TokenLifecycleSink.
+func (s *WebhookSink) Close() error {
+	s.queue.close()
+	return nil
+}
+
+func (s *WebhookSink) deliver(ctx context.Context, event InvalidationEvent) error {
+	body, err := json.Marshal(webhookBatch{Events: []InvalidationEvent{event}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("tokenlc: webhook receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
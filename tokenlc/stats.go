@@ -0,0 +1,55 @@
+package tokenlc
+
+import "sync"
+
+// statsKey identifies one (reason, sink, result) bucket.
+type statsKey struct {
+	reason Reason
+	sink   string
+	result string
+}
+
+// Stats keeps an in-process tally of invalidations alongside the
+// Prometheus counters, so that /api/cleaner/stats can answer "what has
+// this process done" without scraping /metrics.
+type Stats struct {
+	mu     sync.Mutex
+	counts map[statsKey]int64
+}
+
+// NewStats returns an empty Stats.
+func NewStats() *Stats {
+	return &Stats{counts: make(map[statsKey]int64)}
+}
+
+func (s *Stats) record(reason Reason, sink, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[statsKey{reason, sink, result}]++
+}
+
+// Entry is one row of a Stats snapshot.
+type Entry struct {
+	Reason string `json:"reason"`
+	Sink   string `json:"sink"`
+	Result string `json:"result"`
+	Count  int64  `json:"count"`
+}
+
+// Snapshot returns every non-zero bucket as a flat slice, suitable for
+// JSON serialization.
+func (s *Stats) Snapshot() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, 0, len(s.counts))
+	for k, v := range s.counts {
+		entries = append(entries, Entry{
+			Reason: string(k.reason),
+			Sink:   k.sink,
+			Result: k.result,
+			Count:  v,
+		})
+	}
+	return entries
+}
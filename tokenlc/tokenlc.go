@@ -0,0 +1,153 @@
+// Package tokenlc implements the token-lifecycle subsystem: classifying
+// push-provider errors into a stable Reason enum and routing terminal
+// reasons to one or more pluggable sinks (HTTP, webhook, Redis, message
+// queue, or no-op) so that dead tokens get cleaned up out of band instead
+// of being re-sent on every push.
+package tokenlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Reason is a stable, provider-agnostic classification of why a push
+// provider rejected a token. Router code should branch on Reason instead
+// of matching against raw error strings, since provider error text is
+// not a stable API.
+type Reason string
+
+const (
+	// ReasonUnregistered means the token is permanently invalid, e.g. the
+	// user uninstalled the app or the provider revoked the registration.
+	ReasonUnregistered Reason = "unregistered"
+	// ReasonBadToken means the token was malformed or never valid.
+	ReasonBadToken Reason = "bad_token"
+	// ReasonTokenNotForTopic means the token is valid but was not
+	// registered for the topic/app the notification targeted.
+	ReasonTokenNotForTopic Reason = "token_not_for_topic"
+	// ReasonExpiredCredentials means the provider rejected the call
+	// because the sender's own credentials (not the token) expired.
+	ReasonExpiredCredentials Reason = "expired_credentials"
+	// ReasonMismatchedSender means the token was registered against a
+	// different sender/application than the one making the request.
+	ReasonMismatchedSender Reason = "mismatched_sender"
+	// ReasonUnknown is used when the provider error does not map to any
+	// of the reasons above; sinks should treat it as non-terminal.
+	ReasonUnknown Reason = "unknown"
+)
+
+// InvalidationEvent describes a single token that a push provider has
+// reported as no longer valid (or otherwise worth recording).
+type InvalidationEvent struct {
+	Token           string
+	Platform        string
+	AppID           string
+	Topic           string
+	Reason          Reason
+	RawProviderCode string
+	OccurredAt      time.Time
+}
+
+// TokenLifecycleSink receives InvalidationEvents and does something with
+// them: delete the token from a backing store, publish it to a queue, or
+// simply drop it (Noop). Invalidate must not block the caller for long;
+// sinks that talk to the network should queue internally and return
+// quickly, reporting failures through the sink's own metrics/logs rather
+// than through the returned error where retries are possible.
+type TokenLifecycleSink interface {
+	// Name identifies the sink in metrics and logs, e.g. "http", "webhook".
+	Name() string
+	// Invalidate handles a single invalidation event.
+	Invalidate(ctx context.Context, event InvalidationEvent) error
+	// Close releases any resources (queues, connections) held by the sink.
+	Close() error
+}
+
+// TerminalReasons is the default set of reasons that should trigger
+// cleanup. Operators can replace it via config.ConfYaml.Cleaner.Terminal.
+var TerminalReasons = map[Reason]bool{
+	ReasonUnregistered:     true,
+	ReasonBadToken:         true,
+	ReasonTokenNotForTopic: true,
+}
+
+// Router fans an InvalidationEvent out to every configured sink, but only
+// for reasons marked terminal.
+type Router struct {
+	sinks    []TokenLifecycleSink
+	terminal map[Reason]bool
+	stats    *Stats
+}
+
+// NewRouter builds a Router over sinks, using terminal to decide which
+// reasons are forwarded. A nil or empty terminal map falls back to
+// TerminalReasons.
+func NewRouter(sinks []TokenLifecycleSink, terminal map[Reason]bool) *Router {
+	if len(terminal) == 0 {
+		terminal = TerminalReasons
+	}
+	return &Router{sinks: sinks, terminal: terminal, stats: NewStats()}
+}
+
+// Stats returns the Router's in-process invalidation tally, backing the
+// /api/cleaner/stats endpoint.
+func (r *Router) Stats() *Stats {
+	return r.stats
+}
+
+// AddSink registers an additional sink after construction, for callers
+// that assemble part of their sink list from cfg.Cleaner.Engines and
+// part from elsewhere (e.g. the webhooks package's built-in subscriber
+// on the "invalidated" event, which depends on cfg.Webhooks rather than
+// cfg.Cleaner).
+func (r *Router) AddSink(sink TokenLifecycleSink) {
+	r.sinks = append(r.sinks, sink)
+}
+
+// IsTerminal reports whether reason should be forwarded to sinks.
+func (r *Router) IsTerminal(reason Reason) bool {
+	return r.terminal[reason]
+}
+
+// Dispatch forwards event to every sink if its reason is terminal. Each
+// sink is invoked independently so a slow or failing sink cannot block
+// the others; failures are logged and counted but never returned to the
+// caller, since invalidation is best-effort cleanup, not the primary
+// delivery path.
+func (r *Router) Dispatch(ctx context.Context, event InvalidationEvent) {
+	if !r.IsTerminal(event.Reason) {
+		return
+	}
+
+	for _, sink := range r.sinks {
+		sink := sink
+		go func() {
+			result := "ok"
+			if err := sink.Invalidate(ctx, event); err != nil {
+				result = "error"
+				log.Error().Err(err).
+					Str("sink", sink.Name()).
+					Str("token", event.Token).
+					Str("reason", string(event.Reason)).
+					Msg("tokenlc: failed to invalidate token")
+			}
+			observeInvalidation(string(event.Reason), sink.Name(), result)
+			r.stats.record(event.Reason, sink.Name(), result)
+		}()
+	}
+}
+
+// Close closes every sink, returning the last error encountered (if any)
+// so callers can log it; Close is best-effort and always attempts every
+// sink regardless of earlier failures.
+func (r *Router) Close() error {
+	var lastErr error
+	for _, sink := range r.sinks {
+		if err := sink.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
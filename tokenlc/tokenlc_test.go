@@ -0,0 +1,77 @@
+package tokenlc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// recordingSink collects every event it receives onto a channel so tests
+// can observe Dispatch's async delivery without racing on a shared slice.
+type recordingSink struct {
+	name     string
+	received chan InvalidationEvent
+}
+
+func newRecordingSink(name string) *recordingSink {
+	return &recordingSink{name: name, received: make(chan InvalidationEvent, 10)}
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Invalidate(_ context.Context, event InvalidationEvent) error {
+	s.received <- event
+	return nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+func TestRouterDispatchOnlyForwardsTerminalReasons(t *testing.T) {
+	sink := newRecordingSink("test")
+	router := NewRouter([]TokenLifecycleSink{sink}, nil)
+
+	router.Dispatch(context.Background(), InvalidationEvent{Token: "non-terminal", Reason: ReasonUnknown})
+	select {
+	case event := <-sink.received:
+		t.Fatalf("expected no dispatch for non-terminal reason, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	router.Dispatch(context.Background(), InvalidationEvent{Token: "terminal", Reason: ReasonUnregistered})
+	select {
+	case event := <-sink.received:
+		if event.Token != "terminal" {
+			t.Errorf("got token %q, want %q", event.Token, "terminal")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dispatch for terminal reason, got none")
+	}
+}
+
+func TestRouterDispatchFansOutToEverySink(t *testing.T) {
+	a := newRecordingSink("a")
+	b := newRecordingSink("b")
+	router := NewRouter([]TokenLifecycleSink{a, b}, nil)
+
+	router.Dispatch(context.Background(), InvalidationEvent{Token: "t", Reason: ReasonBadToken})
+
+	for _, sink := range []*recordingSink{a, b} {
+		select {
+		case <-sink.received:
+		case <-time.After(time.Second):
+			t.Fatalf("sink %q never received the event", sink.name)
+		}
+	}
+}
+
+func TestRouterCustomTerminalReasons(t *testing.T) {
+	sink := newRecordingSink("custom")
+	router := NewRouter([]TokenLifecycleSink{sink}, map[Reason]bool{ReasonMismatchedSender: true})
+
+	if router.IsTerminal(ReasonUnregistered) {
+		t.Error("ReasonUnregistered should not be terminal under a custom terminal set that omits it")
+	}
+	if !router.IsTerminal(ReasonMismatchedSender) {
+		t.Error("ReasonMismatchedSender should be terminal under the custom terminal set")
+	}
+}
@@ -0,0 +1,21 @@
+package webhooks
+
+import (
+	"math/rand"
+	"time"
+)
+
+// fullJitterBackoff returns a delay for attempt (0-based) using the AWS
+// "full jitter" strategy: a uniformly random duration between 0 and
+// min(max, base*2^attempt). Unlike tokenlc's backoff, which jitters by
+// +/-25% around that delay, this spreads retries across the entire
+// window, which matters more here since a slow subscriber can hold a
+// worker for a while and we want retries from a failed burst to land
+// anywhere in the window rather than clustering.
+func fullJitterBackoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
@@ -0,0 +1,87 @@
+package webhooks
+
+import "encoding/json"
+
+// SubscriberConfig is the shape shared by config.ConfYaml.Webhooks.Default
+// and a per-notification override: where to deliver receipts, what to
+// sign them with, and which events to send.
+type SubscriberConfig struct {
+	URL    string   `yaml:"url" json:"url"`
+	Secret string   `yaml:"secret" json:"secret"`
+	Events []string `yaml:"events" json:"events"`
+}
+
+// subscriberConfigAlias has the same fields as SubscriberConfig but none
+// of its methods, so marshaling one can't recurse back into
+// SubscriberConfig's own MarshalJSON/MarshalYAML.
+type subscriberConfigAlias SubscriberConfig
+
+const redactedSecret = "***"
+
+// MarshalJSON redacts Secret so that serializing a SubscriberConfig --
+// e.g. the config tree served by GET cfg.API.ConfigURI -- never leaks
+// the signing secret alongside it.
+func (c SubscriberConfig) MarshalJSON() ([]byte, error) {
+	redacted := subscriberConfigAlias(c)
+	if redacted.Secret != "" {
+		redacted.Secret = redactedSecret
+	}
+	return json.Marshal(redacted)
+}
+
+// MarshalYAML redacts Secret for the same reason as MarshalJSON.
+func (c SubscriberConfig) MarshalYAML() (interface{}, error) {
+	redacted := subscriberConfigAlias(c)
+	if redacted.Secret != "" {
+		redacted.Secret = redactedSecret
+	}
+	return redacted, nil
+}
+
+// Spec converts a SubscriberConfig into the Spec the Dispatcher expects.
+func (c SubscriberConfig) Spec() Spec {
+	return Spec{URL: c.URL, Secret: c.Secret, Events: eventSet(c.Events)}
+}
+
+// Config is embedded as ConfYaml.Webhooks.
+type Config struct {
+	// Default is used for any notification that does not set its own
+	// callback_url.
+	Default SubscriberConfig `yaml:"default" json:"default"`
+	// MaxAttempts bounds delivery retries before a receipt is handed to
+	// the dead-letter sink; 0 uses defaultMaxAttempts.
+	MaxAttempts int `yaml:"max_attempts" json:"max_attempts"`
+	// Concurrency bounds how many deliveries may be in flight at once.
+	Concurrency int `yaml:"concurrency" json:"concurrency"`
+	// DeadLetter selects where exhausted receipts are recorded: "", "file",
+	// or "redis".
+	DeadLetter struct {
+		Engine string `yaml:"engine" json:"engine"`
+		Path   string `yaml:"path" json:"path"`
+		Redis  struct {
+			Addr string `yaml:"addr" json:"addr"`
+			Key  string `yaml:"key" json:"key"`
+		} `yaml:"redis" json:"redis"`
+	} `yaml:"dead_letter" json:"dead_letter"`
+}
+
+// ResolveSpec returns the Spec a notification should deliver receipts to:
+// its own callback_url/callback_events/callback_secret if set, otherwise
+// cfg.Default. callbackSecret lets a notification that overrides
+// callback_url sign its receipts with a secret of its own instead of
+// falling back to the single shared cfg.Default.Secret -- without it,
+// every tenant-specific callback URL would be signed with the same
+// secret and X-Gorush-Signature couldn't distinguish one tenant's
+// receipts from another's. An empty callbackSecret still falls back to
+// cfg.Default.Secret, for notifications that only want to redirect the
+// destination and are fine sharing the default signing secret.
+func (c Config) ResolveSpec(callbackURL, callbackSecret string, callbackEvents []string) Spec {
+	if callbackURL == "" {
+		return c.Default.Spec()
+	}
+	secret := callbackSecret
+	if secret == "" {
+		secret = c.Default.Secret
+	}
+	return Spec{URL: callbackURL, Secret: secret, Events: eventSet(callbackEvents)}
+}
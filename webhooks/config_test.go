@@ -0,0 +1,39 @@
+package webhooks
+
+import "testing"
+
+func TestResolveSpec(t *testing.T) {
+	cfg := Config{Default: SubscriberConfig{URL: "https://default.example/receipts", Secret: "default-secret"}}
+
+	t.Run("no callback_url falls back to Default", func(t *testing.T) {
+		spec := cfg.ResolveSpec("", "", nil)
+		if spec.URL != cfg.Default.URL || spec.Secret != cfg.Default.Secret {
+			t.Fatalf("expected cfg.Default, got %+v", spec)
+		}
+	})
+
+	t.Run("callback_url with its own secret signs with that secret", func(t *testing.T) {
+		spec := cfg.ResolveSpec("https://tenant-a.example/receipts", "tenant-a-secret", nil)
+		if spec.URL != "https://tenant-a.example/receipts" || spec.Secret != "tenant-a-secret" {
+			t.Fatalf("expected the notification's own URL and secret, got %+v", spec)
+		}
+	})
+
+	t.Run("callback_url with no secret falls back to Default.Secret", func(t *testing.T) {
+		spec := cfg.ResolveSpec("https://tenant-b.example/receipts", "", nil)
+		if spec.URL != "https://tenant-b.example/receipts" {
+			t.Fatalf("expected the notification's own URL, got %q", spec.URL)
+		}
+		if spec.Secret != cfg.Default.Secret {
+			t.Fatalf("expected Secret to fall back to cfg.Default.Secret, got %q", spec.Secret)
+		}
+	})
+
+	t.Run("distinct tenants with their own secrets sign differently", func(t *testing.T) {
+		a := cfg.ResolveSpec("https://tenant-a.example/receipts", "tenant-a-secret", nil)
+		b := cfg.ResolveSpec("https://tenant-b.example/receipts", "tenant-b-secret", nil)
+		if a.Secret == b.Secret {
+			t.Fatal("expected distinct per-tenant secrets to remain distinct")
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package webhooks
+
+import "context"
+
+// DeadLetterSink records a receipt that exhausted every delivery
+// attempt, so an operator can inspect or manually replay it instead of
+// losing it silently.
+type DeadLetterSink interface {
+	Put(ctx context.Context, receipt DeliveryReceipt, spec Spec, lastErr error) error
+}
+
+// NoopDeadLetterSink discards exhausted receipts. It is the default so
+// that an operator who hasn't configured a dead-letter destination still
+// gets a valid, explicit DeadLetterSink rather than a nil check at every
+// call site.
+type NoopDeadLetterSink struct{}
+
+// Put implements DeadLetterSink.
+func (NoopDeadLetterSink) Put(context.Context, DeliveryReceipt, Spec, error) error { return nil }
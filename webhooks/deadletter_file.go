@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileDeadLetterSink appends one JSON line per exhausted receipt to a
+// file, the simplest durable destination for operators who don't run
+// Redis but still want to replay failed deliveries later.
+type FileDeadLetterSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// deadLetterEntry is the JSON line written per exhausted receipt.
+type deadLetterEntry struct {
+	Receipt   DeliveryReceipt `json:"receipt"`
+	URL       string          `json:"url"`
+	LastError string          `json:"last_error"`
+	FailedAt  time.Time       `json:"failed_at"`
+}
+
+// NewFileDeadLetterSink builds a FileDeadLetterSink writing to path,
+// creating it if it does not already exist.
+func NewFileDeadLetterSink(path string) *FileDeadLetterSink {
+	return &FileDeadLetterSink{path: path}
+}
+
+// Put implements DeadLetterSink.
+func (s *FileDeadLetterSink) Put(_ context.Context, receipt DeliveryReceipt, spec Spec, lastErr error) error {
+	line, err := json.Marshal(deadLetterEntry{
+		Receipt:   receipt,
+		URL:       spec.URL,
+		LastError: lastErr.Error(),
+		FailedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(line)
+	return err
+}
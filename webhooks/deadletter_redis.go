@@ -0,0 +1,36 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisDeadLetterSink pushes exhausted receipts onto a Redis list via
+// RPUSH, for operators who already run Redis and want dead letters
+// alongside their other queues instead of on local disk.
+type RedisDeadLetterSink struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisDeadLetterSink builds a RedisDeadLetterSink pushing onto key.
+func NewRedisDeadLetterSink(client *redis.Client, key string) *RedisDeadLetterSink {
+	return &RedisDeadLetterSink{client: client, key: key}
+}
+
+// Put implements DeadLetterSink.
+func (s *RedisDeadLetterSink) Put(ctx context.Context, receipt DeliveryReceipt, spec Spec, lastErr error) error {
+	payload, err := json.Marshal(deadLetterEntry{
+		Receipt:   receipt,
+		URL:       spec.URL,
+		LastError: lastErr.Error(),
+		FailedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.RPush(ctx, s.key, payload).Err()
+}
@@ -0,0 +1,163 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-queue/queue"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 30 * time.Second
+	defaultConcurrency = 4
+	defaultTimeout     = 5 * time.Second
+)
+
+// Dispatcher signs and delivers DeliveryReceipts to subscriber URLs. It
+// queues one task per receipt onto its own golang-queue/queue worker
+// pool -- see NewDispatcherFromConfig, which deliberately does not reuse
+// the push-sending queue, since deliverWithRetry sleeps out the full
+// retry/backoff budget inside a queued task -- and additionally bounds
+// how many of those tasks may be making an HTTP call at once with an
+// internal semaphore, independent of the queue's own worker count.
+type Dispatcher struct {
+	q           *queue.Queue
+	client      *http.Client
+	deadLetter  DeadLetterSink
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	sem         chan struct{}
+}
+
+// DispatcherConfig configures NewDispatcher.
+type DispatcherConfig struct {
+	MaxAttempts int
+	Concurrency int
+	Client      *http.Client
+	DeadLetter  DeadLetterSink
+}
+
+// NewDispatcher builds a Dispatcher that queues work onto q.
+func NewDispatcher(q *queue.Queue, cfg DispatcherConfig) *Dispatcher {
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	deadLetter := cfg.DeadLetter
+	if deadLetter == nil {
+		deadLetter = NoopDeadLetterSink{}
+	}
+
+	return &Dispatcher{
+		q:           q,
+		client:      client,
+		deadLetter:  deadLetter,
+		maxAttempts: maxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDelay:    defaultMaxDelay,
+		sem:         make(chan struct{}, concurrency),
+	}
+}
+
+// Enqueue schedules receipt for delivery to spec if spec wants the
+// receipt's event; it is a no-op otherwise so callers don't need to
+// check Spec.Wants themselves at every call site.
+func (d *Dispatcher) Enqueue(receipt DeliveryReceipt, spec Spec) {
+	if !spec.Wants(receipt.Status) {
+		return
+	}
+
+	if err := d.q.QueueTask(func(context.Context) error {
+		d.deliverWithRetry(receipt, spec)
+		return nil
+	}); err != nil {
+		log.Error().Err(err).
+			Str("token", receipt.Token).
+			Msg("webhooks: failed to queue delivery receipt, dropping")
+	}
+}
+
+// deliverWithRetry is deliberately decoupled from the request context
+// that produced receipt: the client that made the original push call
+// may have long since disconnected by the time a retry fires, but the
+// subscriber still needs to hear about the outcome.
+func (d *Dispatcher) deliverWithRetry(receipt DeliveryReceipt, spec Spec) {
+	var lastErr error
+	for attempt := 0; attempt < d.maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(fullJitterBackoff(attempt-1, d.baseDelay, d.maxDelay))
+		}
+
+		lastErr = d.deliver(context.Background(), receipt, spec)
+		if lastErr == nil {
+			observeAttempt("delivered")
+			return
+		}
+		if attempt+1 < d.maxAttempts {
+			observeAttempt("retry")
+		}
+		log.Warn().Err(lastErr).
+			Str("token", receipt.Token).
+			Int("attempt", attempt+1).
+			Msg("webhooks: delivery attempt failed")
+	}
+
+	observeAttempt("dead_letter")
+	if err := d.deadLetter.Put(context.Background(), receipt, spec, lastErr); err != nil {
+		log.Error().Err(err).
+			Str("token", receipt.Token).
+			Msg("webhooks: failed to record dead-lettered receipt")
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, receipt DeliveryReceipt, spec Spec) error {
+	d.sem <- struct{}{}
+	defer func() { <-d.sem }()
+
+	webhookInflight.Inc()
+	defer webhookInflight.Dec()
+
+	body, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+
+	deliverCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(deliverCtx, http.MethodPost, spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, sign(spec.Secret, body))
+	req.Header.Set(HeaderDelivery, newDeliveryID())
+	req.Header.Set(HeaderEvent, string(receipt.Status))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhooks: subscriber %s returned status %d", spec.URL, resp.StatusCode)
+	}
+	return nil
+}
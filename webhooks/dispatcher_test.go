@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDeadLetterSink records its last Put call so tests can assert a
+// dead-lettered receipt without standing up a real sink.
+type fakeDeadLetterSink struct {
+	mu      sync.Mutex
+	receipt *DeliveryReceipt
+	lastErr error
+}
+
+func (s *fakeDeadLetterSink) Put(_ context.Context, receipt DeliveryReceipt, _ Spec, lastErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.receipt = &receipt
+	s.lastErr = lastErr
+	return nil
+}
+
+func (s *fakeDeadLetterSink) get() (*DeliveryReceipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.receipt, s.lastErr
+}
+
+func newTestDispatcher(deadLetter DeadLetterSink) *Dispatcher {
+	return &Dispatcher{
+		client:      http.DefaultClient,
+		deadLetter:  deadLetter,
+		maxAttempts: 3,
+		baseDelay:   time.Millisecond,
+		maxDelay:    5 * time.Millisecond,
+		sem:         make(chan struct{}, 1),
+	}
+}
+
+func TestDispatcherDeliverWithRetrySucceedsAfterFailures(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get(HeaderSignature) != sign("secret", mustReadBody(r)) {
+			t.Error("delivered request had an incorrect signature")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	deadLetter := &fakeDeadLetterSink{}
+	d := newTestDispatcher(deadLetter)
+	spec := Spec{URL: srv.URL, Secret: "secret"}
+
+	d.deliverWithRetry(DeliveryReceipt{Token: "tok", Status: EventDelivered}, spec)
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected exactly 3 attempts before success, got %d", got)
+	}
+	if receipt, _ := deadLetter.get(); receipt != nil {
+		t.Fatalf("expected no dead-lettered receipt after an eventual success, got %+v", receipt)
+	}
+}
+
+func TestDispatcherDeliverWithRetryDeadLettersAfterExhaustion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	deadLetter := &fakeDeadLetterSink{}
+	d := newTestDispatcher(deadLetter)
+	spec := Spec{URL: srv.URL, Secret: "secret"}
+
+	d.deliverWithRetry(DeliveryReceipt{Token: "tok", Status: EventFailed}, spec)
+
+	receipt, lastErr := deadLetter.get()
+	if receipt == nil {
+		t.Fatal("expected the exhausted receipt to be dead-lettered")
+	}
+	if receipt.Token != "tok" {
+		t.Errorf("dead-lettered receipt token = %q, want %q", receipt.Token, "tok")
+	}
+	if lastErr == nil {
+		t.Error("expected the dead-lettered Put to carry the last delivery error")
+	}
+}
+
+func mustReadBody(r *http.Request) []byte {
+	body, _ := io.ReadAll(r.Body)
+	return body
+}
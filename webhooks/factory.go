@@ -0,0 +1,47 @@
+package webhooks
+
+import (
+	"fmt"
+
+	"github.com/golang-queue/queue"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewDispatcherFromConfig builds a Dispatcher from cfg, queueing
+// deliveries onto a worker pool of its own, sized by cfg.Concurrency
+// (falling back to defaultConcurrency) -- deliberately not the caller's
+// push-sending queue, since deliverWithRetry sleeps out the full
+// retry/backoff budget inside a queued task, and a flaky subscriber
+// stalling every worker in that pool must not be able to starve actual
+// push delivery. "dead_letter.engine: redis" builds a RedisDeadLetterSink
+// on a client for dead_letter.redis.addr, pushing onto
+// dead_letter.redis.key, the same way tokenlc.NewRouterFromConfig builds
+// its redis sink from an address.
+func NewDispatcherFromConfig(cfg Config) (*Dispatcher, error) {
+	var deadLetter DeadLetterSink
+	switch cfg.DeadLetter.Engine {
+	case "", "noop":
+		deadLetter = NoopDeadLetterSink{}
+	case "file":
+		if cfg.DeadLetter.Path == "" {
+			return nil, fmt.Errorf("webhooks: dead_letter.engine is \"file\" but dead_letter.path is empty")
+		}
+		deadLetter = NewFileDeadLetterSink(cfg.DeadLetter.Path)
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.DeadLetter.Redis.Addr})
+		deadLetter = NewRedisDeadLetterSink(client, cfg.DeadLetter.Redis.Key)
+	default:
+		return nil, fmt.Errorf("webhooks: unknown dead_letter.engine %q", cfg.DeadLetter.Engine)
+	}
+
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return NewDispatcher(queue.NewPool(concurrency), DispatcherConfig{
+		MaxAttempts: cfg.MaxAttempts,
+		Concurrency: cfg.Concurrency,
+		DeadLetter:  deadLetter,
+	}), nil
+}
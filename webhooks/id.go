@@ -0,0 +1,25 @@
+package webhooks
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// deliveryIDMu guards deliveryIDEntropy the same way jobs.NewID guards
+// its own entropy source: ulid.Monotonic is not safe for concurrent use.
+var (
+	deliveryIDMu      sync.Mutex
+	deliveryIDEntropy = ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0)
+)
+
+// newDeliveryID returns a unique ID for one delivery attempt, sent as
+// X-Gorush-Delivery so a receiver can dedupe retries of the same
+// receipt.
+func newDeliveryID() string {
+	deliveryIDMu.Lock()
+	defer deliveryIDMu.Unlock()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), deliveryIDEntropy).String()
+}
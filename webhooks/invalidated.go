@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/tolki-app/gorush/tokenlc"
+)
+
+// InvalidatedSink adapts a Dispatcher into a tokenlc.TokenLifecycleSink,
+// turning every terminal token-lifecycle event into an "invalidated"
+// DeliveryReceipt on cfg.Default (or whichever Spec it is built with).
+// Registering it on the cleaner router is what subsumes the old
+// deleteUnregisteredToken HTTP call: operators who want that behavior
+// back now point Default.URL at their own cleanup endpoint instead of
+// gorush hard-coding a DELETE request.
+type InvalidatedSink struct {
+	dispatcher *Dispatcher
+	spec       Spec
+}
+
+// NewInvalidatedSink builds an InvalidatedSink delivering to spec.
+func NewInvalidatedSink(dispatcher *Dispatcher, spec Spec) *InvalidatedSink {
+	return &InvalidatedSink{dispatcher: dispatcher, spec: spec}
+}
+
+// Name implements tokenlc.TokenLifecycleSink.
+func (s *InvalidatedSink) Name() string { return "webhook-receipt" }
+
+// Invalidate implements tokenlc.TokenLifecycleSink.
+func (s *InvalidatedSink) Invalidate(_ context.Context, event tokenlc.InvalidationEvent) error {
+	s.dispatcher.Enqueue(DeliveryReceipt{
+		Token:        event.Token,
+		Platform:     event.Platform,
+		Status:       EventInvalidated,
+		ProviderCode: event.RawProviderCode,
+		Reason:       string(event.Reason),
+		Timestamp:    event.OccurredAt,
+	}, s.spec)
+	return nil
+}
+
+// Close implements tokenlc.TokenLifecycleSink.
+func (s *InvalidatedSink) Close() error { return nil }
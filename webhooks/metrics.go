@@ -0,0 +1,32 @@
+package webhooks
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// webhookAttempts counts every delivery attempt, labelled by outcome:
+// "delivered", "retry" (failed but attempts remain), or "dead_letter"
+// (failed and handed to the DeadLetterSink).
+var webhookAttempts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gorush_webhook_attempts_total",
+		Help: "Total number of webhook delivery attempts, by outcome.",
+	},
+	[]string{"status"},
+)
+
+// webhookInflight tracks how many webhook deliveries are currently being
+// attempted, across every subscriber, so operators can see queue
+// pressure without scraping per-status attempt counts.
+var webhookInflight = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "gorush_webhook_inflight",
+		Help: "Number of webhook deliveries currently in flight.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(webhookAttempts, webhookInflight)
+}
+
+func observeAttempt(status string) {
+	webhookAttempts.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// HeaderSignature carries the hex-encoded HMAC-SHA256 of the request
+	// body, computed with the subscriber's secret.
+	HeaderSignature = "X-Gorush-Signature"
+	// HeaderDelivery carries a unique ID for this delivery attempt,
+	// letting a receiver dedupe retries of the same receipt.
+	HeaderDelivery = "X-Gorush-Delivery"
+	// HeaderEvent carries the DeliveryReceipt.Status value.
+	HeaderEvent = "X-Gorush-Event"
+)
+
+// sign returns the hex-encoded HMAC-SHA256 of body keyed by secret. An
+// empty secret still produces a (predictable) signature rather than
+// skipping the header, so a misconfigured subscriber fails closed on
+// verification instead of silently receiving unsigned payloads.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// Known HMAC-SHA256 vector: HMAC("secret", "payload").
+	const want = "b82fcb791acec57859b989b430a826488ce2e479fdf92326bd0a2e8375a42ba4"
+
+	got := sign("secret", []byte("payload"))
+	if got != want {
+		t.Errorf("sign(%q, %q) = %s, want %s", "secret", "payload", got, want)
+	}
+}
+
+func TestSignIsDeterministicAndKeyDependent(t *testing.T) {
+	body := []byte(`{"token":"abc"}`)
+
+	if sign("secret-a", body) != sign("secret-a", body) {
+		t.Error("expected sign to be deterministic for the same secret and body")
+	}
+	if sign("secret-a", body) == sign("secret-b", body) {
+		t.Error("expected different secrets to produce different signatures")
+	}
+}
@@ -0,0 +1,71 @@
+// Package webhooks delivers push outcomes to operator-owned HTTP
+// endpoints: one event per token result (delivered, failed, or
+// invalidated), HMAC-signed so receivers can verify the payload actually
+// came from this gorush instance. It replaces the old ad-hoc
+// deleteUnregisteredToken call with a general delivery-receipt pipeline
+// that analytics and cleanup consumers can both subscribe to.
+package webhooks
+
+import "time"
+
+// Event identifies which stage of a token's lifecycle a DeliveryReceipt
+// reports on. Operators opt into a subset via callback_events.
+type Event string
+
+const (
+	// EventDelivered means the provider accepted the push for the token.
+	EventDelivered Event = "delivered"
+	// EventFailed means the provider rejected the push for the token, for
+	// any reason, terminal or not; Reason carries the classification.
+	EventFailed Event = "failed"
+	// EventInvalidated means tokenlc classified the provider's rejection
+	// as terminal; this is also fired for tokens tokenlc routes to its
+	// own sinks, so a single subscription can drive both cleanup and
+	// analytics.
+	EventInvalidated Event = "invalidated"
+)
+
+// DeliveryReceipt is the JSON body POSTed to a subscriber's callback URL.
+type DeliveryReceipt struct {
+	JobID        string    `json:"job_id,omitempty"`
+	Token        string    `json:"token"`
+	Platform     string    `json:"platform"`
+	MessageID    string    `json:"message_id,omitempty"`
+	Status       Event     `json:"status"`
+	ProviderCode string    `json:"provider_code,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Spec is the resolved destination for a receipt: where to send it, what
+// secret to sign it with, and which events the subscriber wants. A Spec
+// with an empty URL means delivery is disabled.
+type Spec struct {
+	URL    string
+	Secret string
+	Events map[Event]bool
+}
+
+// Wants reports whether the subscriber behind s asked for event.
+func (s Spec) Wants(event Event) bool {
+	if s.URL == "" {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	return s.Events[event]
+}
+
+// eventSet converts a []string of event names into the map Spec.Events
+// expects, so config and per-notification overrides share one parser.
+func eventSet(events []string) map[Event]bool {
+	if len(events) == 0 {
+		return nil
+	}
+	out := make(map[Event]bool, len(events))
+	for _, e := range events {
+		out[Event(e)] = true
+	}
+	return out
+}